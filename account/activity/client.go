@@ -10,6 +10,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -29,7 +31,7 @@ func NewClient(apiClient *client.Client) *Client {
 func (c *Client) RetrieveAuditLogs(ctx context.Context, opts ...AccountActivityOption) ([]AuditLog, *Paging, error) {
 	req := client.NewRequest("GET", "/account-info/v3/activity/audit-logs")
 	req.WithContext(ctx)
-	req.WithResourceType("accounts")
+	req.WithResourceType("account-activity")
 
 	for _, opt := range opts {
 		opt(req)
@@ -40,22 +42,50 @@ func (c *Client) RetrieveAuditLogs(ctx context.Context, opts ...AccountActivityO
 		return nil, nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
 	}
 
-	var response struct {
-		Results []AuditLog `json:"results"`
-		Paging  *Paging    `json:"paging"`
-	}
+	var response client.CollectionResponse[AuditLog]
 	if err := json.Unmarshal(resp.Body, &response); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal audit logs: %w", err)
 	}
 
-	return response.Results, response.Paging, nil
+	return response.Results, &response.Paging, nil
+}
+
+// StreamAuditLogs retrieves a page of audit logs like RetrieveAuditLogs, passing each log to fn as it is decoded
+// instead of holding the whole page in memory. It stops at the first error returned by fn.
+func (c *Client) StreamAuditLogs(ctx context.Context, fn func(AuditLog) error, opts ...AccountActivityOption) (*Paging, error) {
+	req := client.NewRequest("GET", "/account-info/v3/activity/audit-logs")
+	req.WithContext(ctx)
+	req.WithResourceType("account-activity")
+
+	for _, opt := range opts {
+		opt(req)
+	}
+	client.WithStreaming()(req)
+
+	resp, err := c.apiClient.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
+	}
+	defer resp.Close()
+
+	fields, err := client.DecodeResults(resp.Reader(), fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audit logs: %w", err)
+	}
+
+	var paging *Paging
+	if err := client.DecodeField(fields, "paging", &paging); err != nil {
+		return nil, err
+	}
+
+	return paging, nil
 }
 
 // RetrieveLoginActivity Retrieve logs of user actions related to login activity.
 func (c *Client) RetrieveLoginActivity(ctx context.Context, opts ...AccountActivityOption) ([]LoginActivity, *Paging, error) {
 	req := client.NewRequest("GET", "/account-info/v3/activity/login")
 	req.WithContext(ctx)
-	req.WithResourceType("accounts")
+	req.WithResourceType("account-activity")
 
 	for _, opt := range opts {
 		opt(req)
@@ -66,22 +96,19 @@ func (c *Client) RetrieveLoginActivity(ctx context.Context, opts ...AccountActiv
 		return nil, nil, fmt.Errorf("failed to retrieve login activity: %w", err)
 	}
 
-	var response struct {
-		Results []LoginActivity `json:"results"`
-		Paging  *Paging         `json:"paging"`
-	}
+	var response client.CollectionResponse[LoginActivity]
 	if err := json.Unmarshal(resp.Body, &response); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal login activity: %w", err)
 	}
 
-	return response.Results, response.Paging, nil
+	return response.Results, &response.Paging, nil
 }
 
 // RetrieveSecurityHistory Retrieve logs of user actions related to security activity.
 func (c *Client) RetrieveSecurityHistory(ctx context.Context, opts ...AccountActivityOption) ([]SecurityHistory, *Paging, error) {
 	req := client.NewRequest("GET", "/account-info/v3/activity/security")
 	req.WithContext(ctx)
-	req.WithResourceType("accounts")
+	req.WithResourceType("account-activity")
 
 	for _, opt := range opts {
 		opt(req)
@@ -92,13 +119,47 @@ func (c *Client) RetrieveSecurityHistory(ctx context.Context, opts ...AccountAct
 		return nil, nil, fmt.Errorf("failed to retrieve security history: %w", err)
 	}
 
-	var response struct {
-		Results []SecurityHistory `json:"results"`
-		Paging  *Paging           `json:"paging"`
-	}
+	var response client.CollectionResponse[SecurityHistory]
 	if err := json.Unmarshal(resp.Body, &response); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal security history: %w", err)
 	}
 
-	return response.Results, response.Paging, nil
+	return response.Results, &response.Paging, nil
+}
+
+// AllAuditLogs iterates over every audit log, fetching the next page once a page has been consumed
+//
+// opts: same as RetrieveAuditLogs. WithAfter sets the cursor of the first page.
+func (c *Client) AllAuditLogs(ctx context.Context, opts ...AccountActivityOption) iter.Seq2[AuditLog, error] {
+	return paginate(ctx, c.RetrieveAuditLogs, opts)
+}
+
+// AllLoginActivity iterates over every login activity log, fetching the next page once a page has been consumed
+//
+// opts: same as RetrieveLoginActivity. WithAfter sets the cursor of the first page.
+func (c *Client) AllLoginActivity(ctx context.Context, opts ...AccountActivityOption) iter.Seq2[LoginActivity, error] {
+	return paginate(ctx, c.RetrieveLoginActivity, opts)
+}
+
+// AllSecurityHistory iterates over every security activity log, fetching the next page once a page has been consumed
+//
+// opts: same as RetrieveSecurityHistory. WithAfter sets the cursor of the first page.
+func (c *Client) AllSecurityHistory(ctx context.Context, opts ...AccountActivityOption) iter.Seq2[SecurityHistory, error] {
+	return paginate(ctx, c.RetrieveSecurityHistory, opts)
+}
+
+// paginate iterates over an activity endpoint paged with an after cursor
+func paginate[T any](ctx context.Context, retrieve func(context.Context, ...AccountActivityOption) ([]T, *Paging, error), opts []AccountActivityOption) iter.Seq2[T, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[T], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		results, paging, err := retrieve(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[T]{}, err
+		}
+		return client.Page[T]{Results: results, Next: paging.NextAfter()}, nil
+	})
 }
@@ -1,5 +1,7 @@
 package activity
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 type AuditLog struct {
 	ActingUser     ActingUser `json:"actingUser"`
 	Action         string     `json:"action"`
@@ -15,12 +17,7 @@ type ActingUser struct {
 	UserEmail string `json:"userEmail"`
 }
 
-type Paging struct {
-	Next struct {
-		After string `json:"after"`
-		Link  string `json:"link"`
-	} `json:"next"`
-}
+type Paging = client.Paging
 
 type LoginActivity struct {
 	ID             string `json:"id"`
@@ -8,7 +8,7 @@ import (
 )
 
 // AccountActivityOption is a functional option for RetrieveAuditLogs
-type AccountActivityOption func(*client.Request)
+type AccountActivityOption = client.CallOption
 
 // WithActingUserID The ID of a user, for retrieving user-specific logs.
 func WithActingUserID(actingUserID int) AccountActivityOption {
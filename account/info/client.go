@@ -25,11 +25,15 @@ func NewClient(apiClient *client.Client) *Client {
 	}
 }
 
-func (c *Client) GetAccountDetails(ctx context.Context) (*AccountDetails, error) {
+func (c *Client) GetAccountDetails(ctx context.Context, opts ...client.CallOption) (*AccountDetails, error) {
 	req := client.NewRequest("GET", "/account-info/v3/details")
 	req.WithContext(ctx)
 	req.WithResourceType("accounts")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -44,11 +48,15 @@ func (c *Client) GetAccountDetails(ctx context.Context) (*AccountDetails, error)
 }
 
 // RetrievePrivateAppDailyAPIUsage returns the daily rate limits and usage for legacy private-apps
-func (c *Client) RetrievePrivateAppDailyAPIUsage(ctx context.Context) ([]PrivateAppAPIUsage, error) {
+func (c *Client) RetrievePrivateAppDailyAPIUsage(ctx context.Context, opts ...client.CallOption) ([]PrivateAppAPIUsage, error) {
 	req := client.NewRequest("GET", "/account-info/v3/api-usage/daily/private-apps")
 	req.WithContext(ctx)
 	req.WithResourceType("accounts")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
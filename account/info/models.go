@@ -1,5 +1,11 @@
 package info
 
+import (
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
+
 type AccountType string
 
 const (
@@ -21,6 +27,14 @@ type AccountDetails struct {
 	DataHostingLocation   string      `json:"dataHostingLocation"`
 }
 
+// Location returns the account's time zone, falling back to a fixed offset if the zone name is unknown
+func (d *AccountDetails) Location() *time.Location {
+	if loc, err := client.LoadAccountLocation(d.TimeZone); err == nil {
+		return loc
+	}
+	return time.FixedZone(d.UTCOffset, d.UTCOffsetMilliseconds/1000)
+}
+
 type PrivateAppAPIUsage struct {
 	Name         string `json:"name"`
 	UsageLimit   int    `json:"usageLimit"`
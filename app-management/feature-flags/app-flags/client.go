@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
+	"strconv"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -21,11 +24,15 @@ func NewClient(apiClient *client.Client) *Client {
 }
 
 // RetrieveAppFeatureFlags Retrieve the current status of the app’s feature flags. No request body is included.
-func (c *Client) RetrieveAppFeatureFlags(ctx context.Context, appID int, flagName string) (*FlagInfo, error) {
+func (c *Client) RetrieveAppFeatureFlags(ctx context.Context, appID int, flagName string, opts ...client.CallOption) (*FlagInfo, error) {
 	req := client.NewRequest("GET", fmt.Sprintf("/app-management/v3/apps/%d/feature-flags/%s", appID, flagName))
 	req.WithContext(ctx)
 	req.WithResourceType("feature-flags")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve app feature flags: %w", err)
@@ -45,6 +52,10 @@ func (c *Client) RetrieveAccountsWithSetFlagState(ctx context.Context, appID int
 	req.WithContext(ctx)
 	req.WithResourceType("feature-flags")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve app feature flags: %w", err)
@@ -59,3 +70,32 @@ func (c *Client) RetrieveAccountsWithSetFlagState(ctx context.Context, appID int
 
 	return response.PortalFlagStates, nil
 }
+
+// AllAccountsWithSetFlagState iterates over every HubSpot account with an account-level flag setting for the
+// specified app, fetching the next page once a page has been consumed. Pages are requested by portal ID, starting
+// after the last portal of the previous page, until a page is empty.
+//
+// opts: same as RetrieveAccountsWithSetFlagState. WithStartPortalID sets the first portal.
+func (c *Client) AllAccountsWithSetFlagState(ctx context.Context, appID int, flagName string, opts ...AppFlagOption) iter.Seq2[FlagState, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[FlagState], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			startPortalID, err := strconv.Atoi(cursor)
+			if err != nil {
+				return client.Page[FlagState]{}, fmt.Errorf("invalid start portal ID %q: %w", cursor, err)
+			}
+			pageOpts = append(pageOpts, WithStartPortalID(startPortalID))
+		}
+
+		states, err := c.RetrieveAccountsWithSetFlagState(ctx, appID, flagName, pageOpts...)
+		if err != nil {
+			return client.Page[FlagState]{}, err
+		}
+
+		next := ""
+		if len(states) > 0 {
+			next = strconv.Itoa(states[len(states)-1].PortalID + 1)
+		}
+		return client.Page[FlagState]{Results: states, Next: next}, nil
+	})
+}
@@ -0,0 +1,43 @@
+package appflags
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAllAccountsWithSetFlagState tests that AllAccountsWithSetFlagState pages with startPortalId
+func TestAllAccountsWithSetFlagState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("startPortalId") {
+		case "":
+			_, _ = w.Write([]byte(`{"portalFlagStates": [{"PortalId": 10}]}`))
+		case "11":
+			_, _ = w.Write([]byte(`{"portalFlagStates": []}`))
+		default:
+			t.Errorf("unexpected startPortalId %q", r.URL.Query().Get("startPortalId"))
+		}
+	}))
+	defer server.Close()
+
+	apiClient, err := client.NewClient(
+		client.WithBaseURL(server.URL),
+		client.WithAccessToken("test-token"),
+		client.WithRateLimitEnabled(false),
+		client.WithRetryEnabled(false),
+	)
+	require.NoError(t, err)
+
+	var portalIDs []int
+	for state, err := range NewClient(apiClient).AllAccountsWithSetFlagState(context.Background(), 1, "flag") {
+		require.NoError(t, err)
+		portalIDs = append(portalIDs, state.PortalID)
+	}
+	assert.Equal(t, []int{10}, portalIDs)
+}
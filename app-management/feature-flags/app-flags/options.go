@@ -7,7 +7,7 @@ import (
 )
 
 // AppFlagOption is a functional option for RetrieveAppFeatureFlags
-type AppFlagOption func(*client.Request)
+type AppFlagOption = client.CallOption
 
 func WithLimit(limit int) AppFlagOption {
 	return func(req *client.Request) {
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// lowRemainingFraction is the fraction of the window budget below which requests are paced evenly over the window
+const lowRemainingFraction = 0.2
+
+// AdaptiveLimiter is implemented by Limiters that retune themselves from the X-HubSpot-RateLimit headers of each response
+type AdaptiveLimiter interface {
+	Limiter
+	Adapt(info RateLimitInfo)
+}
+
+// windowInterval returns the rate limit window from the response headers, defaulting to 10 seconds
+func windowInterval(info RateLimitInfo) time.Duration {
+	if info.IntervalMs <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(info.IntervalMs) * time.Millisecond
+}
+
+// pacedRate returns the requests per second to allow for the budget reported in the headers.
+// Once Remaining drops below lowRemainingFraction of Max, the remaining requests are spread evenly over the window.
+func pacedRate(info RateLimitInfo) float64 {
+	interval := windowInterval(info).Seconds()
+	if float64(info.Remaining) >= float64(info.Max)*lowRemainingFraction {
+		return float64(info.Max) / interval
+	}
+	return float64(max(info.Remaining, 1)) / interval
+}
+
+// adaptiveRateLimiter is the default in-process Limiter, a rate.Limiter retuned from response headers
+type adaptiveRateLimiter struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+}
+
+// newAdaptiveRateLimiter creates a limiter allowing maxBurst requests per 10 seconds until headers say otherwise
+func newAdaptiveRateLimiter(maxBurst int) *adaptiveRateLimiter {
+	// Convert requests per 10 seconds to per-second rate
+	requestsPerSecond := float64(maxBurst) / 10.0
+
+	return &adaptiveRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), maxBurst),
+	}
+}
+
+// Wait implements Limiter
+func (l *adaptiveRateLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// Adapt implements AdaptiveLimiter
+func (l *adaptiveRateLimiter) Adapt(info RateLimitInfo) {
+	if info.Max <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.limiter.Burst() != info.Max {
+		l.limiter.SetBurstAt(now, info.Max)
+	}
+	l.limiter.SetLimitAt(now, rate.Limit(pacedRate(info)))
+
+	// Other consumers of the same budget may have used tokens this limiter still holds
+	if excess := int(l.limiter.TokensAt(now)) - info.Remaining; excess > 0 {
+		l.limiter.ReserveN(now, excess)
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// TestPacedRate tests the request rate derived from rate limit headers
+func TestPacedRate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		info     RateLimitInfo
+		expected float64
+	}{
+		{"Standard app", RateLimitInfo{Max: 100, Remaining: 90, IntervalMs: 10000}, 10},
+		{"Professional app", RateLimitInfo{Max: 190, Remaining: 190, IntervalMs: 10000}, 19},
+		{"Low remaining is paced", RateLimitInfo{Max: 100, Remaining: 10, IntervalMs: 10000}, 1},
+		{"Exhausted", RateLimitInfo{Max: 100, Remaining: 0, IntervalMs: 10000}, 0.1},
+		{"Missing interval", RateLimitInfo{Max: 100, Remaining: 100}, 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, pacedRate(tc.info), 0.0001)
+		})
+	}
+}
+
+// TestAdaptiveRateLimiter tests retuning the default limiter from headers
+func TestAdaptiveRateLimiter(t *testing.T) {
+	t.Run("Honors higher per-app limits", func(t *testing.T) {
+		l := newAdaptiveRateLimiter(100)
+		l.Adapt(RateLimitInfo{Max: 190, Remaining: 190, IntervalMs: 10000})
+
+		assert.Equal(t, 190, l.limiter.Burst())
+		assert.InDelta(t, 19, float64(l.limiter.Limit()), 0.0001)
+	})
+
+	t.Run("Slows down as remaining approaches zero", func(t *testing.T) {
+		l := newAdaptiveRateLimiter(100)
+		l.Adapt(RateLimitInfo{Max: 100, Remaining: 5, IntervalMs: 10000})
+
+		assert.InDelta(t, 0.5, float64(l.limiter.Limit()), 0.0001)
+		assert.InDelta(t, 5, l.limiter.Tokens(), 0.01)
+	})
+
+	t.Run("Ignores responses without headers", func(t *testing.T) {
+		l := newAdaptiveRateLimiter(100)
+		l.Adapt(RateLimitInfo{IntervalMs: 10000})
+
+		assert.Equal(t, 100, l.limiter.Burst())
+		assert.InDelta(t, 10, float64(l.limiter.Limit()), 0.0001)
+	})
+
+	t.Run("UpdateFromResponse adapts the limiter", func(t *testing.T) {
+		rl := NewRateLimiter(100)
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{
+			Max:            190,
+			Remaining:      189,
+			IntervalMs:     10000,
+			DailyLimit:     500000,
+			DailyRemaining: 499999,
+		}})
+
+		adaptive, ok := rl.limiter.(*adaptiveRateLimiter)
+		require.True(t, ok)
+		assert.Equal(t, 190, adaptive.limiter.Burst())
+	})
+
+	t.Run("Plain rate.Limiter is not retuned", func(t *testing.T) {
+		limiter := rate.NewLimiter(10, 100)
+		rl := NewRateLimiterWithLimiter(limiter)
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{Max: 190, Remaining: 190, IntervalMs: 10000}})
+
+		assert.Equal(t, 100, limiter.Burst())
+	})
+}
+
+// TestStoreLimiter_Adapt tests retuning a shared limiter from headers
+func TestStoreLimiter_Adapt(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	limiter, err := NewStoreLimiter(store, "portal-1", 100, 10*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	// Another consumer outside the store used most of the budget
+	limiter.Adapt(RateLimitInfo{Max: 190, Remaining: 1, IntervalMs: 10000})
+
+	assert.InDelta(t, 190, limiter.burst, 0)
+	assert.Equal(t, 10*time.Second, limiter.perToken)
+
+	require.NoError(t, limiter.Wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheConfig configures the response cache
+//
+// Only GET requests of the resource types listed in TTLs are cached. Any other request to one of those
+// resource types invalidates its cached responses, whether or not it succeeded, since a failed write may
+// still have been applied. Read-only POSTs such as searches and batch reads are marked with
+// Request.WithReadOnly and leave the cache alone.
+type CacheConfig struct {
+	// TTLs maps resource types, e.g. "schemas", to how long their responses are cached
+	TTLs map[string]time.Duration
+
+	// Store holds cached responses, defaults to an LRUCacheStore with MaxEntries entries
+	Store CacheStore
+
+	// Namespace prefixes every key, so clients of different portals or credentials can share a Store without
+	// serving each other's responses. It is required with a Store. Pool clients append their portal ID.
+	Namespace string
+
+	// MaxEntries bounds the default store, defaults to 1000
+	MaxEntries int
+}
+
+// CacheResult is the outcome of a cacheable request
+type CacheResult string
+
+const (
+	// CacheHit means the response was served from the cache
+	CacheHit CacheResult = "hit"
+	// CacheMiss means the response was fetched from HubSpot
+	CacheMiss CacheResult = "miss"
+	// CacheShared means the request waited for an identical request that was already in flight and shared its response
+	CacheShared CacheResult = "shared"
+	// CacheBypass means the request skipped the cache with WithoutCache and was fetched from HubSpot
+	CacheBypass CacheResult = "bypass"
+)
+
+// CacheEvent describes a cacheable request
+type CacheEvent struct {
+	ResourceType string
+	PathTemplate string
+	Result       CacheResult
+}
+
+// CacheObserver is implemented by Instrumentations that also want to observe the response cache, e.g. PrometheusMetrics
+type CacheObserver interface {
+	ObserveCache(ctx context.Context, event CacheEvent)
+}
+
+// CacheStats counts the outcomes of cacheable requests
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Shared        uint64
+	Bypassed      uint64
+	Invalidations uint64
+}
+
+// responseCache caches GET responses and deduplicates identical concurrent GETs
+type responseCache struct {
+	store     CacheStore
+	namespace string
+	ttls      map[string]time.Duration
+	observer  CacheObserver
+	log       *requestLogger
+
+	mu          sync.Mutex
+	flights     map[string]*cacheFlight
+	generations map[string]uint64 // bumped on every invalidation of a resource type
+
+	hits, misses, shared, bypassed, invalidations atomic.Uint64
+}
+
+// cacheFlight is a GET in flight whose response is shared with identical requests
+type cacheFlight struct {
+	done chan struct{}
+	resp *Response
+	err  error
+}
+
+// newResponseCache creates the cache for cfg
+func newResponseCache(cfg CacheConfig, observer CacheObserver, log *requestLogger) *responseCache {
+	store := cfg.Store
+	if store == nil {
+		maxEntries := cfg.MaxEntries
+		if maxEntries <= 0 {
+			maxEntries = 1000
+		}
+		store = NewLRUCacheStore(maxEntries)
+	}
+
+	return &responseCache{
+		store:       store,
+		namespace:   cfg.Namespace,
+		ttls:        cfg.TTLs,
+		observer:    observer,
+		log:         log,
+		flights:     make(map[string]*cacheFlight),
+		generations: make(map[string]uint64),
+	}
+}
+
+// wrapCacheMiddleware serves cached GET responses and invalidates them after writes
+func (c *Client) wrapCacheMiddleware(next Handler) Handler {
+	if c.cache == nil {
+		return next
+	}
+
+	return func(req *Request) (*Response, error) {
+		ttl, ok := c.cache.ttls[req.ResourceType]
+		if !ok || (req.Stream && req.Method == http.MethodGet) {
+			return next(req)
+		}
+
+		if req.Method != http.MethodGet {
+			if req.ReadOnly {
+				return next(req)
+			}
+			resp, err := next(req)
+			if invalidateErr := c.cache.invalidate(req.Context, req.ResourceType); invalidateErr != nil {
+				c.log.log(req, slog.LevelWarn, "Failed to invalidate cached responses", slog.Any("error", invalidateErr))
+			}
+			return resp, err
+		}
+
+		return c.cache.do(req, ttl, next)
+	}
+}
+
+// do serves a GET from the cache, from an identical request in flight, or from next
+func (rc *responseCache) do(req *Request, ttl time.Duration, next Handler) (*Response, error) {
+	key := rc.key(req)
+
+	if !req.SkipCache {
+		entry, ok, err := rc.store.Get(req.Context, key)
+		if err != nil {
+			rc.log.log(req, slog.LevelWarn, "Failed to read cached response", slog.Any("error", err))
+		}
+		if ok && !entry.expired(time.Now()) {
+			rc.observe(req, CacheHit)
+			return entry.response(), nil
+		}
+	}
+
+	rc.mu.Lock()
+	if flight, ok := rc.flights[key]; ok && !req.SkipCache {
+		rc.mu.Unlock()
+
+		select {
+		case <-flight.done:
+		case <-req.Context.Done():
+			return nil, req.Context.Err()
+		}
+
+		// The request in flight was canceled by its caller; this one is still live so it makes its own request
+		if !isContextError(flight.err) || req.Context.Err() != nil {
+			rc.observe(req, CacheShared)
+			return flight.resp.clone(), flight.err
+		}
+		rc.mu.Lock()
+	}
+
+	// Requests bypassing the cache still refresh it, but don't serve other requests
+	flight := &cacheFlight{done: make(chan struct{})}
+	if !req.SkipCache {
+		rc.flights[key] = flight
+	}
+	generation := rc.generations[req.ResourceType]
+	rc.mu.Unlock()
+
+	if req.SkipCache {
+		rc.observe(req, CacheBypass)
+	} else {
+		rc.observe(req, CacheMiss)
+	}
+	resp, err := next(req)
+	if err == nil && resp != nil && resp.StatusCode == http.StatusOK {
+		rc.set(req, key, generation, ttl, resp)
+	}
+
+	flight.resp, flight.err = resp, err
+	rc.mu.Lock()
+	if rc.flights[key] == flight {
+		delete(rc.flights, key)
+	}
+	rc.mu.Unlock()
+	close(flight.done)
+
+	return resp, err
+}
+
+// set stores a response unless its resource type was invalidated while it was fetched
+func (rc *responseCache) set(req *Request, key string, generation uint64, ttl time.Duration, resp *Response) {
+	entry := CacheEntry{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers.Clone(),
+		Body:       bytes.Clone(resp.Body),
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+
+	if !rc.current(req.ResourceType, generation) {
+		return
+	}
+	if err := rc.store.Set(req.Context, key, entry); err != nil {
+		rc.log.log(req, slog.LevelWarn, "Failed to cache response", slog.Any("error", err))
+		return
+	}
+
+	// An invalidation that ran while the entry was being stored may have missed it
+	if !rc.current(req.ResourceType, generation) {
+		_ = rc.store.Delete(req.Context, key)
+	}
+}
+
+// current reports whether a resource type is still at generation
+func (rc *responseCache) current(resourceType string, generation uint64) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.generations[resourceType] == generation
+}
+
+// invalidate drops every cached response of a resource type
+func (rc *responseCache) invalidate(ctx context.Context, resourceType string) error {
+	rc.mu.Lock()
+	rc.generations[resourceType]++
+	rc.mu.Unlock()
+
+	rc.invalidations.Add(1)
+	return rc.store.DeletePrefix(ctx, rc.prefix(resourceType))
+}
+
+// observe counts a cache result and reports it to the observer
+func (rc *responseCache) observe(req *Request, result CacheResult) {
+	switch result {
+	case CacheHit:
+		rc.hits.Add(1)
+	case CacheMiss:
+		rc.misses.Add(1)
+	case CacheShared:
+		rc.shared.Add(1)
+	case CacheBypass:
+		rc.bypassed.Add(1)
+	}
+
+	if rc.observer != nil {
+		rc.observer.ObserveCache(req.Context, CacheEvent{
+			ResourceType: req.ResourceType,
+			PathTemplate: PathTemplate(req.Path),
+			Result:       result,
+		})
+	}
+}
+
+// stats returns the counters
+func (rc *responseCache) stats() CacheStats {
+	return CacheStats{
+		Hits:          rc.hits.Load(),
+		Misses:        rc.misses.Load(),
+		Shared:        rc.shared.Load(),
+		Bypassed:      rc.bypassed.Load(),
+		Invalidations: rc.invalidations.Load(),
+	}
+}
+
+// InvalidateCache drops the cached responses of the given resource types, e.g. after changing them outside this client
+func (c *Client) InvalidateCache(ctx context.Context, resourceTypes ...string) error {
+	if c.cache == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, resourceType := range resourceTypes {
+		errs = append(errs, c.cache.invalidate(ctx, resourceType))
+	}
+	return errors.Join(errs...)
+}
+
+// CacheStats returns the response cache counters, which are zero if the cache is disabled
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+// key identifies a GET by namespace, resource type, path and query
+func (rc *responseCache) key(req *Request) string {
+	query := make(url.Values, len(req.QueryParams))
+	for key, value := range req.QueryParams {
+		query.Set(key, value)
+	}
+	return rc.prefix(req.ResourceType) + req.Path + "?" + query.Encode()
+}
+
+// prefix starts the keys of every response of a resource type
+func (rc *responseCache) prefix(resourceType string) string {
+	if rc.namespace == "" {
+		return resourceType + ":"
+	}
+	return rc.namespace + ":" + resourceType + ":"
+}
+
+// response builds the response served for a cache hit
+func (e CacheEntry) response() *Response {
+	resp := NewResponse(e.StatusCode, bytes.Clone(e.Body), e.Headers.Clone())
+	resp.Cached = true
+	return resp
+}
+
+// clone copies a response shared with several callers
+func (r *Response) clone() *Response {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	clone.Body = bytes.Clone(r.Body)
+	clone.Headers = r.Headers.Clone()
+	return &clone
+}
+
+// isContextError reports whether err was caused by a canceled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
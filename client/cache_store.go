@@ -0,0 +1,136 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a response stored in a CacheStore
+type CacheEntry struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+	ExpiresAt  time.Time   `json:"expiresAt"`
+}
+
+// expired reports whether the entry is past its TTL
+func (e CacheEntry) expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// CacheStore holds cached responses
+//
+// Keys start with the namespace of the cache, if any, and the resource type of the request, each followed by a
+// colon, so DeletePrefix can drop every response of a resource type. Stores may return expired entries; the cache checks ExpiresAt itself.
+// Implementations must be safe for concurrent use by multiple goroutines.
+type CacheStore interface {
+	// Get returns the entry stored under key, or false if there is none
+	Get(ctx context.Context, key string) (CacheEntry, bool, error)
+	Set(ctx context.Context, key string, entry CacheEntry) error
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix deletes every entry whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently used entry once full
+type LRUCacheStore struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+// lruItem is an element of LRUCacheStore.order
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCacheStore creates an LRUCacheStore holding up to maxEntries responses
+func NewLRUCacheStore(maxEntries int) *LRUCacheStore {
+	return &LRUCacheStore{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements CacheStore
+func (s *LRUCacheStore) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return CacheEntry{}, false, nil
+	}
+	item := elem.Value.(*lruItem)
+	if item.entry.expired(time.Now()) {
+		s.removeLocked(elem)
+		return CacheEntry{}, false, nil
+	}
+
+	s.order.MoveToFront(elem)
+	return item.entry, true, nil
+}
+
+// Set implements CacheStore
+func (s *LRUCacheStore) Set(ctx context.Context, key string, entry CacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		elem.Value.(*lruItem).entry = entry
+		s.order.MoveToFront(elem)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&lruItem{key: key, entry: entry})
+	for s.maxEntries > 0 && s.order.Len() > s.maxEntries {
+		s.removeLocked(s.order.Back())
+	}
+	return nil
+}
+
+// Delete implements CacheStore
+func (s *LRUCacheStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.removeLocked(elem)
+	}
+	return nil
+}
+
+// DeletePrefix implements CacheStore
+func (s *LRUCacheStore) DeletePrefix(ctx context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, elem := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			s.removeLocked(elem)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired entries that haven't been evicted yet
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+// removeLocked removes an element. s.mu must be held.
+func (s *LRUCacheStore) removeLocked(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*lruItem).key)
+}
@@ -0,0 +1,345 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCacheTestClient returns a client caching schemas and a counter of requests that reached the server
+func newCacheTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) (*httptest.Server, *Client, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler(w, r)
+	}))
+
+	c, err := NewClient(append([]Option{
+		WithBaseURL(server.URL),
+		WithRateLimitEnabled(false),
+		WithRetryEnabled(false),
+		WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": time.Minute}}),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	return server, c, &requests
+}
+
+// cacheRequest builds a request for the given resource type
+func cacheRequest(method, path, resourceType string) *Request {
+	return NewRequest(method, path).WithResourceType(resourceType)
+}
+
+// TestResponseCache tests serving, expiring and invalidating cached responses
+func TestResponseCache(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{"results": []}`)
+	}
+
+	t.Run("Serves repeated GETs from the cache", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		first, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		assert.False(t, first.Cached)
+
+		second, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		assert.True(t, second.Cached)
+		assert.Equal(t, first.Body, second.Body)
+
+		assert.Equal(t, int32(1), requests.Load())
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, c.CacheStats())
+	})
+
+	t.Run("Query parameters are part of the key", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		_, err = c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas").AddQueryParam("archived", "true"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Resource types without a TTL are not cached", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		for range 2 {
+			resp, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/objects/contacts", "objects"))
+			require.NoError(t, err)
+			assert.False(t, resp.Cached)
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Error responses are not cached", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusNotFound, `{"status": "error", "message": "not found"}`)
+		})
+		defer server.Close()
+
+		for range 2 {
+			_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas/missing", "schemas"))
+			require.Error(t, err)
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Entries expire after their TTL", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok, WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": 20 * time.Millisecond}}))
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		resp, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+
+		assert.False(t, resp.Cached)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("Writes invalidate the resource type", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		_, err = c.Do(context.Background(), cacheRequest(http.MethodPatch, "/crm/v3/schemas/p_cars", "schemas"))
+		require.NoError(t, err)
+		resp, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+
+		assert.False(t, resp.Cached)
+		assert.Equal(t, int32(3), requests.Load())
+		assert.Equal(t, uint64(1), c.CacheStats().Invalidations)
+	})
+
+	t.Run("Read-only POSTs don't invalidate the resource type", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		_, err = c.Do(context.Background(), cacheRequest(http.MethodPost, "/crm/v3/schemas/search", "schemas").WithReadOnly())
+		require.NoError(t, err)
+		resp, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+
+		assert.True(t, resp.Cached)
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Zero(t, c.CacheStats().Invalidations)
+	})
+
+	t.Run("InvalidateCache", func(t *testing.T) {
+		server, c, requests := newCacheTestClient(t, ok)
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		require.NoError(t, c.InvalidateCache(context.Background(), "schemas"))
+		_, err = c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("WithoutCache refreshes the entry", func(t *testing.T) {
+		var version atomic.Int32
+		server, c, requests := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if version.Add(1) == 1 {
+				respondJSON(w, http.StatusOK, `{"version": 1}`)
+				return
+			}
+			respondJSON(w, http.StatusOK, `{"version": 2}`)
+		})
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+
+		req := cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas")
+		WithoutCache()(req)
+		fresh, err := c.Do(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, fresh.Cached)
+
+		cached, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		assert.True(t, cached.Cached)
+		assert.JSONEq(t, `{"version": 2}`, string(cached.Body))
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Bypassed: 1}, c.CacheStats())
+	})
+}
+
+// TestResponseCache_Singleflight tests that identical concurrent GETs share one request
+func TestResponseCache_Singleflight(t *testing.T) {
+	release := make(chan struct{})
+	server, c, requests := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		respondJSON(w, http.StatusOK, `{"results": []}`)
+	})
+	defer server.Close()
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+			errs <- err
+		}()
+	}
+
+	// Wait for every caller to either lead or join the flight
+	require.Eventually(t, func() bool {
+		stats := c.CacheStats()
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		return stats.Misses == 1 && len(c.cache.flights) == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+	stats := c.CacheStats()
+	assert.Equal(t, uint64(callers), stats.Misses+stats.Shared+stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+}
+
+// TestLRUCacheStore tests eviction and prefix deletion
+func TestLRUCacheStore(t *testing.T) {
+	ctx := context.Background()
+	entry := CacheEntry{StatusCode: http.StatusOK, ExpiresAt: time.Now().Add(time.Minute)}
+
+	t.Run("Evicts the least recently used entry", func(t *testing.T) {
+		store := NewLRUCacheStore(2)
+		require.NoError(t, store.Set(ctx, "schemas:a", entry))
+		require.NoError(t, store.Set(ctx, "schemas:b", entry))
+		_, ok, _ := store.Get(ctx, "schemas:a")
+		require.True(t, ok)
+		require.NoError(t, store.Set(ctx, "schemas:c", entry))
+
+		_, ok, _ = store.Get(ctx, "schemas:b")
+		assert.False(t, ok)
+		_, ok, _ = store.Get(ctx, "schemas:a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("Drops expired entries", func(t *testing.T) {
+		store := NewLRUCacheStore(2)
+		require.NoError(t, store.Set(ctx, "schemas:a", CacheEntry{ExpiresAt: time.Now().Add(-time.Second)}))
+
+		_, ok, _ := store.Get(ctx, "schemas:a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, store.Len())
+	})
+
+	t.Run("DeletePrefix", func(t *testing.T) {
+		store := NewLRUCacheStore(10)
+		require.NoError(t, store.Set(ctx, "schemas:a", entry))
+		require.NoError(t, store.Set(ctx, "schemas:b", entry))
+		require.NoError(t, store.Set(ctx, "accounts:a", entry))
+
+		require.NoError(t, store.DeletePrefix(ctx, "schemas:"))
+		assert.Equal(t, 1, store.Len())
+		_, ok, _ := store.Get(ctx, "accounts:a")
+		assert.True(t, ok)
+	})
+}
+
+// TestPrometheusMetrics_Cache tests the cache result counter
+func TestPrometheusMetrics_Cache(t *testing.T) {
+	metrics := NewPrometheusMetrics()
+	server, c, _ := newCacheTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{}`)
+	}, WithInstrumentation(metrics))
+	defer server.Close()
+
+	for range 3 {
+		_, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+	}
+
+	var b strings.Builder
+	require.NoError(t, metrics.WriteText(&b))
+	assert.Contains(t, b.String(), `hubspot_api_cache_requests_total{method="GET",path="/crm/v3/schemas",resource_type="schemas",result="hit"} 2`)
+	assert.Contains(t, b.String(), `hubspot_api_cache_requests_total{method="GET",path="/crm/v3/schemas",resource_type="schemas",result="miss"} 1`)
+}
+
+// TestWithCache tests cache config validation
+func TestWithCache(t *testing.T) {
+	_, err := NewClient(WithCache(CacheConfig{}))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": 0}}))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": time.Minute}, MaxEntries: -1}))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": time.Minute}, Store: NewLRUCacheStore(10)}))
+	assert.ErrorContains(t, err, "namespace")
+}
+
+// TestResponseCache_SharedStore tests that clients sharing a store only serve their own namespace
+func TestResponseCache_SharedStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{"results": [{"name": "`+r.Header.Get("Authorization")+`"}]}`)
+	}))
+	defer server.Close()
+
+	store := NewLRUCacheStore(10)
+	newClient := func(namespace, token string) *Client {
+		c, err := NewClient(
+			WithBaseURL(server.URL),
+			WithAccessToken(token),
+			WithRetryEnabled(false),
+			WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": time.Minute}, Store: store, Namespace: namespace}),
+		)
+		require.NoError(t, err)
+		return c
+	}
+	get := func(c *Client) *Response {
+		resp, err := c.Do(context.Background(), cacheRequest(http.MethodGet, "/crm/v3/schemas", "schemas"))
+		require.NoError(t, err)
+		return resp
+	}
+
+	first, second := newClient("portal-1", "token-1"), newClient("portal-2", "token-2")
+	get(first)
+	resp := get(second)
+	assert.False(t, resp.Cached)
+	assert.Contains(t, string(resp.Body), "token-2")
+	assert.True(t, get(first).Cached)
+	assert.Equal(t, 2, store.Len())
+
+	// Invalidation only drops the responses of its own namespace
+	require.NoError(t, second.InvalidateCache(context.Background(), "schemas"))
+	assert.Equal(t, 1, store.Len())
+	assert.True(t, get(first).Cached)
+}
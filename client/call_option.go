@@ -0,0 +1,65 @@
+package client
+
+import "time"
+
+// CallOption customizes a single API call. Every service method accepts CallOptions, and the
+// resource-specific options of the service packages (e.g. objects.WithProperties) are CallOptions too.
+type CallOption func(*Request)
+
+// WithCallTimeout bounds the call, including retries and rate limit waits
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(req *Request) {
+		req.Timeout = timeout
+	}
+}
+
+// WithHeader sets an extra header on the call
+func WithHeader(key, value string) CallOption {
+	return func(req *Request) {
+		req.AddHeader(key, value)
+	}
+}
+
+// WithoutRetry disables retries for the call
+func WithoutRetry() CallOption {
+	return func(req *Request) {
+		req.RetryPolicy = NoRetry
+	}
+}
+
+// WithoutRateLimit sends the call without waiting for the rate limiters or checking the daily quota,
+// e.g. for health checks. The response still updates the tracked quota.
+func WithoutRateLimit() CallOption {
+	return func(req *Request) {
+		req.SkipRateLimit = true
+	}
+}
+
+// WithoutCache fetches a fresh response instead of serving a cached one. The response still refreshes the cache.
+func WithoutCache() CallOption {
+	return func(req *Request) {
+		req.SkipCache = true
+	}
+}
+
+// WithPriority sets the scheduling class of the call, e.g. PriorityHigh for user-facing reads
+func WithPriority(priority Priority) CallOption {
+	return func(req *Request) {
+		req.Priority = priority
+	}
+}
+
+// WithStreaming leaves a successful response body unread so it can be decoded as it arrives, e.g. with DecodeResults.
+// Streamed responses bypass the response cache and must be closed.
+func WithStreaming() CallOption {
+	return func(req *Request) {
+		req.Stream = true
+	}
+}
+
+// WithCallRetryPolicy overrides the client's retry policy for the call
+func WithCallRetryPolicy(policy RetryPolicy) CallOption {
+	return func(req *Request) {
+		req.RetryPolicy = policy
+	}
+}
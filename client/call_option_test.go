@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCallOptions tests the generic per-call options
+func TestCallOptions(t *testing.T) {
+	apply := func(opts ...CallOption) *Request {
+		req := NewRequest(http.MethodGet, "/test")
+		for _, opt := range opts {
+			opt(req)
+		}
+		return req
+	}
+
+	t.Run("WithHeader", func(t *testing.T) {
+		var received string
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Get("X-Request-Source")
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), apply(WithHeader("X-Request-Source", "sync-job")))
+		require.NoError(t, err)
+		assert.Equal(t, "sync-job", received)
+	})
+
+	t.Run("WithCallTimeout", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		start := time.Now()
+		_, err := c.Do(context.Background(), apply(WithCallTimeout(20*time.Millisecond)))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("WithoutRetry", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 2)
+		defer server.Close()
+
+		c, err := NewClient(
+			WithBaseURL(server.URL),
+			WithRateLimitEnabled(false),
+			WithRetryMaxAttempts(3),
+			WithRetryBackoff(10*time.Millisecond, 100*time.Millisecond),
+		)
+		require.NoError(t, err)
+
+		_, err = c.Do(context.Background(), apply(WithoutRetry()))
+		require.Error(t, err)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("WithoutRateLimit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-HubSpot-RateLimit-Daily", "1000")
+			w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "0")
+			respondJSON(w, http.StatusOK, `{}`)
+		}))
+		defer server.Close()
+
+		c, err := NewClient(WithBaseURL(server.URL), WithRetryEnabled(false))
+		require.NoError(t, err)
+
+		// The first response exhausts the daily quota
+		_, err = c.Do(context.Background(), apply())
+		require.NoError(t, err)
+
+		_, err = c.Do(context.Background(), apply())
+		var hubspotErr *HubSpotError
+		require.ErrorAs(t, err, &hubspotErr)
+		assert.Equal(t, http.StatusTooManyRequests, hubspotErr.Status)
+
+		resp, err := c.Do(context.Background(), apply(WithoutRateLimit()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,316 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker scope
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request fast with a *CircuitOpenError
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to test whether HubSpot recovered
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen matches every *CircuitOpenError with errors.Is
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned without calling HubSpot while the circuit for a scope is open
+type CircuitOpenError struct {
+	Scope string
+	// OpenUntil is when the circuit half-opens and lets a probe request through
+	OpenUntil time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s until %s", e.Scope, e.OpenUntil.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig configures the circuit breaker stage
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens a circuit, defaults to 5
+	FailureThreshold int
+
+	// OpenTimeout is how long a circuit stays open before half-opening, defaults to 30 seconds
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is the number of concurrent probe requests allowed while half-open, defaults to 1.
+	// A successful probe closes the circuit and a failed one opens it again.
+	HalfOpenProbes int
+
+	// Scope returns the circuit a request belongs to, defaults to CircuitScopeResourceType
+	Scope func(req *Request) string
+
+	// IsFailure reports whether an attempt counts as a failure, defaults to IsCircuitFailure
+	IsFailure func(req *Request, resp *Response, err error) bool
+
+	// OnStateChange is called whenever the circuit for a scope changes state. It should not block.
+	OnStateChange func(scope string, from, to CircuitState)
+}
+
+// CircuitScopeResourceType scopes circuits by the request's resource type, falling back to the path template
+func CircuitScopeResourceType(req *Request) string {
+	if req.ResourceType != "" {
+		return req.ResourceType
+	}
+	return CircuitScopePathTemplate(req)
+}
+
+// CircuitScopePathTemplate scopes circuits by the request method and path template
+func CircuitScopePathTemplate(req *Request) string {
+	return req.Method + " " + PathTemplate(req.Path)
+}
+
+// PathTemplate replaces the IDs in a request path with "{id}", e.g.
+// "/crm/v3/objects/contacts/123" becomes "/crm/v3/objects/contacts/{id}".
+// OAuth tokens in paths such as "/oauth/v1/access-tokens/{token}" are replaced too, so they never reach telemetry.
+func PathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isPathID(segment) || i > 0 && isTokenCollection(segments[i-1]) && segment != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isTokenCollection reports whether a path segment is followed by an OAuth token
+func isTokenCollection(segment string) bool {
+	return segment == "access-tokens" || segment == "refresh-tokens"
+}
+
+// isPathID reports whether a path segment looks like an ID rather than a fixed part of the endpoint:
+// numeric IDs, object type IDs such as "2-123456", UUIDs and email addresses used as idProperty
+func isPathID(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	if strings.Contains(segment, "@") || strings.Contains(segment, "%40") {
+		return true
+	}
+
+	digits, dashes := 0, 0
+	for _, r := range segment {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '-':
+			dashes++
+		case (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F'):
+			// Only allowed in UUIDs
+		default:
+			return false
+		}
+	}
+	return digits > 0 && (digits+dashes == len(segment) || len(segment) == 36)
+}
+
+// IsCircuitFailure reports whether an attempt failed because HubSpot is unhealthy: a 5xx response or a network timeout.
+// Timeouts caused by the caller's own context are not failures.
+func IsCircuitFailure(req *Request, resp *Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode >= http.StatusInternalServerError
+	}
+	if err == nil || (req.Context != nil && req.Context.Err() != nil) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// circuitBreaker tracks one circuit per scope
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit is the state of a single scope
+type circuit struct {
+	state     CircuitState
+	failures  int
+	openUntil time.Time
+	probes    int
+}
+
+// stateChange is a transition to report once the lock is released
+type stateChange struct {
+	scope    string
+	from, to CircuitState
+}
+
+// newCircuitBreaker applies the config defaults
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	if cfg.Scope == nil {
+		cfg.Scope = CircuitScopeResourceType
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = IsCircuitFailure
+	}
+
+	return &circuitBreaker{
+		config:   cfg,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// State returns the state of a scope
+func (b *circuitBreaker) State(scope string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[scope]; ok {
+		if c.state == CircuitOpen && !time.Now().Before(c.openUntil) {
+			return CircuitHalfOpen
+		}
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// allow reports whether a request for scope may be sent, and whether it is a half-open probe
+func (b *circuitBreaker) allow(scope string) (bool, error) {
+	b.mu.Lock()
+	c, ok := b.circuits[scope]
+	if !ok {
+		c = &circuit{}
+		b.circuits[scope] = c
+	}
+
+	var change *stateChange
+	if c.state == CircuitOpen && !time.Now().Before(c.openUntil) {
+		change = b.transitionLocked(scope, c, CircuitHalfOpen)
+	}
+
+	var probe bool
+	var err error
+	switch c.state {
+	case CircuitOpen:
+		err = &CircuitOpenError{Scope: scope, OpenUntil: c.openUntil}
+	case CircuitHalfOpen:
+		if c.probes >= b.config.HalfOpenProbes {
+			err = &CircuitOpenError{Scope: scope, OpenUntil: c.openUntil}
+		} else {
+			c.probes++
+			probe = true
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(change)
+	return probe, err
+}
+
+// record updates the circuit for scope with the outcome of an attempt
+func (b *circuitBreaker) record(scope string, probe, failure, success bool) {
+	b.mu.Lock()
+	c := b.circuits[scope]
+	if probe {
+		c.probes--
+	}
+
+	var change *stateChange
+	switch {
+	case failure:
+		c.failures++
+		if c.state == CircuitHalfOpen || c.failures >= b.config.FailureThreshold {
+			c.openUntil = time.Now().Add(b.config.OpenTimeout)
+			change = b.transitionLocked(scope, c, CircuitOpen)
+		}
+	case success:
+		c.failures = 0
+		if c.state == CircuitHalfOpen && probe {
+			change = b.transitionLocked(scope, c, CircuitClosed)
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(change)
+}
+
+// transitionLocked moves a circuit to a new state. b.mu must be held.
+func (b *circuitBreaker) transitionLocked(scope string, c *circuit, to CircuitState) *stateChange {
+	if c.state == to {
+		return nil
+	}
+	change := &stateChange{scope: scope, from: c.state, to: to}
+	c.state = to
+	if to == CircuitClosed {
+		c.failures = 0
+	}
+	return change
+}
+
+// notify reports a state change outside of the lock
+func (b *circuitBreaker) notify(change *stateChange) {
+	if change != nil && b.config.OnStateChange != nil {
+		b.config.OnStateChange(change.scope, change.from, change.to)
+	}
+}
+
+// wrapCircuitBreakerMiddleware wraps the HTTP handler with the circuit breaker, if one is configured
+//
+// It runs once per attempt, so every failed retry counts towards opening the circuit, and the
+// *CircuitOpenError returned while open is not retried.
+func (c *Client) wrapCircuitBreakerMiddleware(next Handler) Handler {
+	if c.breaker == nil {
+		return next
+	}
+
+	return func(req *Request) (*Response, error) {
+		scope := c.breaker.config.Scope(req)
+		probe, err := c.breaker.allow(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next(req)
+
+		failure := c.breaker.config.IsFailure(req, resp, err)
+		// Cancelled requests and transport errors other than timeouts say nothing about HubSpot's health
+		success := !failure && resp != nil
+		c.breaker.record(scope, probe, failure, success)
+
+		return resp, err
+	}
+}
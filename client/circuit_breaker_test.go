@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPathTemplate tests replacing IDs in request paths
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/crm/v3/objects/contacts":                                      "/crm/v3/objects/contacts",
+		"/crm/v3/objects/contacts/123":                                  "/crm/v3/objects/contacts/{id}",
+		"/crm/v3/objects/contacts/jane@example.com":                     "/crm/v3/objects/contacts/{id}",
+		"/crm/v4/objects/deals/42/associations/companies":               "/crm/v4/objects/deals/{id}/associations/companies",
+		"/crm/v3/schemas/2-123456":                                      "/crm/v3/schemas/{id}",
+		"/oauth/v1/refresh-tokens/8b6c5d1e-3f2a-4b7c-9d8e-1a2b3c4d5e6f": "/oauth/v1/refresh-tokens/{id}",
+		"/crm/v3/objects/contacts/batch/read":                           "/crm/v3/objects/contacts/batch/read",
+		"/oauth/v1/access-tokens/CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf":  "/oauth/v1/access-tokens/{id}",
+	}
+
+	for path, expected := range tests {
+		assert.Equal(t, expected, PathTemplate(path), path)
+	}
+}
+
+// TestCircuitBreaker tests opening, failing fast, half-opening and closing
+func TestCircuitBreaker(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	attempts := 0
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		respondJSON(w, status, `{}`)
+	})
+	defer server.Close()
+
+	var changes []string
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRateLimitEnabled(false),
+		WithRetryEnabled(false),
+		WithCircuitBreaker(CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenTimeout:      50 * time.Millisecond,
+			OnStateChange: func(scope string, from, to CircuitState) {
+				changes = append(changes, scope+": "+from.String()+" -> "+to.String())
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	do := func(resourceType string) error {
+		_, err := c.Do(context.Background(), NewRequest("GET", "/test").WithResourceType(resourceType))
+		return err
+	}
+
+	for range 3 {
+		require.Error(t, do("contacts"))
+	}
+	assert.Equal(t, CircuitOpen, c.CircuitState("contacts"))
+	assert.Equal(t, []string{"contacts: closed -> open"}, changes)
+
+	// Open circuits fail fast without calling HubSpot
+	err = do("contacts")
+	var openErr *CircuitOpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, "contacts", openErr.Scope)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, attempts)
+
+	// Other scopes are unaffected
+	assert.Equal(t, CircuitClosed, c.CircuitState("companies"))
+	require.Error(t, do("companies"))
+	assert.Equal(t, 4, attempts)
+
+	// A failed probe opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, c.CircuitState("contacts"))
+	err = do("contacts")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, CircuitOpen, c.CircuitState("contacts"))
+
+	// A successful probe closes it
+	time.Sleep(60 * time.Millisecond)
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	require.NoError(t, do("contacts"))
+	assert.Equal(t, CircuitClosed, c.CircuitState("contacts"))
+
+	assert.Equal(t, []string{
+		"contacts: closed -> open",
+		"contacts: open -> half-open",
+		"contacts: half-open -> open",
+		"contacts: open -> half-open",
+		"contacts: half-open -> closed",
+	}, changes)
+}
+
+// TestCircuitBreaker_ConsecutiveFailures tests that successes and client errors reset the failure count
+func TestCircuitBreaker_ConsecutiveFailures(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2})
+
+	for _, failure := range []bool{true, false, true, false, true} {
+		probe, err := b.allow("deals")
+		require.NoError(t, err)
+		b.record("deals", probe, failure, !failure)
+	}
+	assert.Equal(t, CircuitClosed, b.State("deals"))
+
+	assert.False(t, IsCircuitFailure(NewRequest("GET", "/"), &Response{StatusCode: http.StatusBadRequest}, &HubSpotError{Status: 400}))
+	assert.False(t, IsCircuitFailure(NewRequest("GET", "/"), &Response{StatusCode: http.StatusTooManyRequests}, &HubSpotError{Status: 429}))
+	assert.True(t, IsCircuitFailure(NewRequest("GET", "/"), &Response{StatusCode: http.StatusBadGateway}, &HubSpotError{Status: 502}))
+}
+
+// TestCircuitBreaker_HalfOpenProbes tests that only the configured number of probes are let through
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond, HalfOpenProbes: 2})
+
+	probe, err := b.allow("tickets")
+	require.NoError(t, err)
+	b.record("tickets", probe, true, false)
+	time.Sleep(5 * time.Millisecond)
+
+	first, err := b.allow("tickets")
+	require.NoError(t, err)
+	assert.True(t, first)
+	second, err := b.allow("tickets")
+	require.NoError(t, err)
+	assert.True(t, second)
+	_, err = b.allow("tickets")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+}
@@ -22,10 +22,17 @@ import (
 
 // Client represents a HubSpot API client
 type Client struct {
-	config      *Config
-	httpClient  *http.Client
-	rateLimiter *RateLimiter
-	logger      *slog.Logger
+	config          *Config
+	httpClient      *http.Client
+	rateLimiter     *RateLimiter
+	retryPolicy     RetryPolicy
+	breaker         *circuitBreaker
+	scheduler       *scheduler
+	cache           *responseCache
+	instrumentation Instrumentation
+	policies        *policyLimiters
+	tokenSource     TokenSource
+	log             *requestLogger
 }
 
 // Handler represents a function that processes a Request and returns a Response
@@ -44,22 +51,100 @@ func NewClient(opts ...Option) (*Client, error) {
 
 	// Validate required config
 	httpClient := &http.Client{
-		Timeout: cfg.Timeout,
+		Timeout:   cfg.Timeout,
+		Transport: cfg.Transport,
 	}
 
 	// Create rate limiter
-	rateLimiter := NewRateLimiter(cfg.RateLimit.MaxBurst)
+	var rateLimiter *RateLimiter
+	switch {
+	case cfg.RateLimit.Limiter != nil:
+		rateLimiter = NewRateLimiterWithLimiter(cfg.RateLimit.Limiter)
+	case cfg.RateLimit.Store != nil:
+		limiter, err := NewStoreLimiter(cfg.RateLimit.Store, cfg.RateLimit.StoreKey, cfg.RateLimit.MaxBurst, 10*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		rateLimiter = NewRateLimiterWithLimiter(limiter)
+	default:
+		rateLimiter = NewRateLimiter(cfg.RateLimit.MaxBurst)
+	}
+	if cfg.RateLimit.DailyLimit > 0 {
+		rateLimiter.SetDailyLimit(cfg.RateLimit.DailyLimit)
+	}
+	rateLimiter.SetResetLocation(cfg.RateLimit.Location)
+	if cfg.RateLimit.OnQuotaThreshold != nil {
+		rateLimiter.SetQuotaThresholds(cfg.RateLimit.OnQuotaThreshold, cfg.RateLimit.QuotaThresholds...)
+	}
+
+	policies, err := newPolicyLimiters(cfg.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	retryPolicy := cfg.Retry.Policy
+	if retryPolicy == nil {
+		retryPolicy = NewDefaultRetryPolicy(cfg.Retry)
+	}
+
+	var breaker *circuitBreaker
+	if cfg.CircuitBreaker != nil {
+		breaker = newCircuitBreaker(*cfg.CircuitBreaker)
+	}
+
+	var sched *scheduler
+	if len(cfg.RateLimit.Priority.Weights) > 0 {
+		sched = newScheduler(cfg.RateLimit.Priority)
+	}
+
+	log := newRequestLogger(cfg.Logger, cfg.Logging)
+
+	var cache *responseCache
+	if cfg.Cache != nil {
+		if cfg.Cache.Store != nil && cfg.Cache.Namespace == "" {
+			return nil, fmt.Errorf("cache namespace is required with a cache store")
+		}
+		observer, _ := cfg.Instrumentation.(CacheObserver)
+		cache = newResponseCache(*cfg.Cache, observer, log)
+	}
+
+	// A static access token is used if no token source is configured
+	tokenSource := cfg.TokenSource
+	if tokenSource == nil && cfg.AccessToken != "" {
+		tokenSource = StaticTokenSource(cfg.AccessToken)
+	}
 
 	return &Client{
-		config:      cfg,
-		httpClient:  httpClient,
-		rateLimiter: rateLimiter,
-		logger:      cfg.Logger,
+		config:          cfg,
+		httpClient:      httpClient,
+		rateLimiter:     rateLimiter,
+		retryPolicy:     retryPolicy,
+		breaker:         breaker,
+		scheduler:       sched,
+		cache:           cache,
+		instrumentation: cfg.Instrumentation,
+		policies:        policies,
+		tokenSource:     tokenSource,
+		log:             log,
 	}, nil
 }
 
 // Do executes the request with context using the core client and responds with the response and/or an error
 func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+
+		req.Context = ctx
+		resp, err := c.buildChain()(req)
+		if resp != nil && resp.BodyStream != nil {
+			// The timeout keeps bounding the call until the streamed body is closed
+			resp.BodyStream = &cancelOnClose{ReadCloser: resp.BodyStream, cancel: cancel}
+		} else {
+			cancel()
+		}
+		return resp, err
+	}
 	req.Context = ctx
 
 	// Build and execute middleware chain
@@ -71,19 +156,46 @@ func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
 func (c *Client) buildChain() Handler {
 	// Start with the HTTP handler (innermost)
 	handler := c.httpMiddleware()
+	handler = c.wrapCircuitBreakerMiddleware(handler)
+	handler = c.wrapAttemptInstrumentation(handler)
+	handler = c.wrapMiddleware(StagePerAttempt, handler)
 
 	// Wrap with retry middleware
 	handler = c.wrapRetryMiddleware(handler)
+	handler = c.wrapMiddleware(StageAfterRateLimit, handler)
 
 	// Wrap with rate limit middleware
 	handler = c.wrapRateLimitMiddleware(handler)
+	handler = c.wrapMiddleware(StageAfterAuth, handler)
 
 	// Wrap with auth middleware
 	handler = c.wrapAuthMiddleware(handler)
+	handler = c.wrapCacheMiddleware(handler)
+	handler = c.wrapCallInstrumentation(handler)
+	handler = c.wrapMiddleware(StageOutermost, handler)
 
 	return handler
 }
 
+// QuotaSnapshot returns the daily quota state as of the last response
+func (c *Client) QuotaSnapshot() QuotaSnapshot {
+	return c.rateLimiter.Snapshot()
+}
+
+// SetAccountTimeZone sets the time zone whose midnight resets the daily quota,
+// e.g. the location of info.AccountDetails once it has been fetched
+func (c *Client) SetAccountTimeZone(loc *time.Location) {
+	c.rateLimiter.SetResetLocation(loc)
+}
+
+// CircuitState returns the circuit breaker state of a scope, or CircuitClosed if no circuit breaker is configured
+func (c *Client) CircuitState(scope string) CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State(scope)
+}
+
 // PrintRateLimit is used to test and verify the rate limiter is being properly updated
 func (c *Client) PrintRateLimit(writers ...io.Writer) {
 	if len(writers) == 0 {
@@ -103,11 +215,42 @@ func (c *Client) PrintRateLimit(writers ...io.Writer) {
 }
 
 // wrapAuthMiddleware wraps a handler with authentication
+//
+// If the token source can invalidate tokens, a 401 response causes a single retry with a refreshed token
 func (c *Client) wrapAuthMiddleware(next Handler) Handler {
 	return func(req *Request) (*Response, error) {
-		if c.config.AccessToken != "" {
-			req.AddHeader("Authorization", fmt.Sprintf("Bearer %s", c.config.AccessToken))
+		if c.tokenSource == nil {
+			return next(req)
+		}
+
+		token, err := c.tokenSource.Token(req.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get access token: %w", err)
+		}
+		req.AddHeader("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+
+		resp, err := next(req)
+		if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		invalidator, ok := c.tokenSource.(TokenInvalidator)
+		if !ok {
+			return resp, err
+		}
+
+		// Invalidate reports false when a concurrent request already replaced the rejected token,
+		// which is still worth retrying with
+		rejected := token.AccessToken
+		invalidator.Invalidate(rejected)
+		token, tokenErr := c.tokenSource.Token(req.Context)
+		if tokenErr != nil || token.AccessToken == rejected {
+			return resp, err
 		}
+
+		c.log.log(req, slog.LevelDebug, "Access token rejected, retrying with a refreshed token")
+		req.AddHeader("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+
 		return next(req)
 	}
 }
@@ -119,6 +262,14 @@ func (c *Client) wrapRateLimitMiddleware(next Handler) Handler {
 			return next(req)
 		}
 
+		if req.SkipRateLimit {
+			resp, err := next(req)
+			if resp != nil {
+				c.rateLimiter.UpdateFromResponse(resp)
+			}
+			return resp, err
+		}
+
 		if !c.rateLimiter.CheckDailyLimit() {
 			return nil, &HubSpotError{
 				Status:      429,
@@ -129,9 +280,35 @@ func (c *Client) wrapRateLimitMiddleware(next Handler) Handler {
 			}
 		}
 
-		if err := c.rateLimiter.Wait(req.Context); err != nil {
+		if c.reserved(req) {
+			return nil, &HubSpotError{
+				Status:      429,
+				Message:     "Remaining daily API quota is reserved for high priority requests",
+				ErrorType:   "RATE_LIMIT",
+				PolicyName:  "DAILY",
+				IsRetryable: false,
+			}
+		}
+
+		// The turn is taken before any limiter so policy buckets are also drawn from in priority order
+		waitStart := time.Now()
+		if c.scheduler != nil {
+			if err := c.scheduler.acquire(req.Context, req.priority()); err != nil {
+				return nil, err
+			}
+			defer c.scheduler.done()
+		}
+		err := c.policies.Wait(req.Context, req)
+		if err == nil {
+			err = c.rateLimiter.Wait(req.Context)
+		}
+		if c.scheduler != nil {
+			c.scheduler.tokenTaken()
+		}
+		if err != nil {
 			return nil, err
 		}
+		req.rateLimitWait += time.Since(waitStart)
 
 		resp, err := next(req)
 
@@ -150,40 +327,40 @@ func (c *Client) wrapRetryMiddleware(next Handler) Handler {
 			return next(req)
 		}
 
-		var lastErr error
-		var lastResp *Response
+		policy := c.retryPolicy
+		if req.RetryPolicy != nil {
+			policy = req.RetryPolicy
+		}
+		maxElapsed := c.config.Retry.MaxElapsedTime
+		start := time.Now()
 
-		for attempt := 0; attempt < c.config.Retry.MaxAttempts; attempt++ {
+		for attempt := 0; ; attempt++ {
 			req.RetryCount = attempt
 
 			resp, err := next(req)
+			if err == nil || attempt >= c.config.Retry.MaxAttempts-1 {
+				return resp, err
+			}
 
-			if err == nil {
-				return resp, nil
+			backoff, retry := policy.Retry(req, resp, err, attempt)
+			if !retry {
+				return resp, err
 			}
 
-			lastResp = resp
-			lastErr = err
-
-			if hubspotErr, ok := err.(*HubSpotError); ok {
-				if !hubspotErr.IsRetryable {
-					return resp, err
-				}
-
-				if attempt < c.config.Retry.MaxAttempts-1 {
-					backoff := calculateBackoffDuration(attempt, hubspotErr.RetryAfter, c.config.Retry)
-					select {
-					case <-time.After(backoff):
-					case <-req.Context.Done():
-						return lastResp, req.Context.Err()
-					}
-				}
-			} else {
+			// Give up early rather than wait past the elapsed time budget
+			if maxElapsed > 0 && time.Since(start)+backoff > maxElapsed {
+				c.log.log(req, slog.LevelDebug, "Retry budget exhausted", slog.Duration("elapsed", time.Since(start)), slog.Duration("backoff", backoff))
 				return resp, err
 			}
-		}
 
-		return lastResp, lastErr
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-req.Context.Done():
+				timer.Stop()
+				return resp, req.Context.Err()
+			}
+		}
 	}
 }
 
@@ -230,20 +407,23 @@ func (c *Client) httpMiddleware() Handler {
 
 		// Prepare request body
 		var bodyReader io.Reader
+		var bodyBytes []byte
 		if req.Body != nil {
-			bodyBytes, err := marshalRequestBody(req.Body)
+			var err error
+			bodyBytes, err = marshalRequestBody(req.Body)
 			if err != nil {
-				c.logger.Error("Failed to marshal request body", "Error", err)
+				c.log.log(req, slog.LevelError, "Failed to marshal request body", slog.Any("error", err))
 				return nil, fmt.Errorf("failed to marshal request body: %w", err)
 			}
 			bodyReader = bytes.NewReader(bodyBytes)
+			req.bodySize = len(bodyBytes)
 			req.AddHeader("Content-Type", "application/json")
 		}
 
 		// Create HTTP request
 		httpReq, err := http.NewRequestWithContext(req.Context, req.Method, fullURL, bodyReader)
 		if err != nil {
-			c.logger.Error("Failed to create request", "Error", err)
+			c.log.log(req, slog.LevelError, "Failed to create request", slog.Any("error", err))
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
@@ -254,26 +434,44 @@ func (c *Client) httpMiddleware() Handler {
 
 		// Set default headers
 		httpReq.Header.Set("User-Agent", "go-hubspot-sdk/1.0")
+		if c.config.Compression && httpReq.Header.Get("Accept-Encoding") == "" {
+			httpReq.Header.Set("Accept-Encoding", "gzip")
+		}
 
-		c.logger.Debug("Making API Request!", slog.Group("Request Data", "Request Method", req.Method, "Request URL", fullURL, "Request Headers", httpReq.Header))
+		c.log.logRequest(req, httpReq, bodyBytes)
 
 		// Perform request
 		httpResp, err := c.httpClient.Do(httpReq)
 		if err != nil {
-			c.logger.Error("HTTP request failed", "Error", err)
+			c.log.log(req, slog.LevelError, "HTTP request failed", slog.Any("error", err))
 			return nil, fmt.Errorf("HTTP request failed: %w", err)
 		}
+
+		// Successful streamed responses are handed to the caller unread
+		if req.Stream && httpResp.StatusCode < 400 {
+			body, err := responseBodyReader(httpResp)
+			if err != nil {
+				c.log.log(req, slog.LevelError, "Failed to read response body", slog.Any("error", err))
+				return nil, err
+			}
+			resp := NewResponse(httpResp.StatusCode, nil, httpResp.Header)
+			resp.RateLimit = ExtractRateLimitInfo(httpResp.Header)
+			resp.BodyStream = body
+			c.log.logResponse(req, resp)
+			return resp, nil
+		}
+
 		defer func() {
 			err = httpResp.Body.Close()
 			if err != nil {
-				c.logger.Error("Failed to close response body", "Error", err)
+				c.log.log(req, slog.LevelError, "Failed to close response body", slog.Any("error", err))
 			}
 		}()
 
 		// Read response body
 		respBodyBytes, err := readResponseBody(httpResp)
 		if err != nil {
-			c.logger.Error("Failed to read response body", "Error", err)
+			c.log.log(req, slog.LevelError, "Failed to read response body", slog.Any("error", err))
 			return nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 
@@ -281,15 +479,14 @@ func (c *Client) httpMiddleware() Handler {
 		resp := NewResponse(httpResp.StatusCode, respBodyBytes, httpResp.Header)
 		resp.RateLimit = ExtractRateLimitInfo(httpResp.Header)
 
-		c.logger.Debug("Response Received!", "Response", *resp)
-
 		// Handle error responses
 		if httpResp.StatusCode >= 400 {
 			resp.HubSpotError = ParseHubSpotError(httpResp.StatusCode, respBodyBytes, httpResp.Header)
-			c.logger.Error("Error Response Received!", "Status Code", httpResp.StatusCode, "Error", resp.HubSpotError)
+			c.log.logResponse(req, resp)
 			return resp, resp.HubSpotError
 		}
 
+		c.log.logResponse(req, resp)
 		return resp, nil
 	}
 }
@@ -306,31 +503,15 @@ func marshalRequestBody(body any) ([]byte, error) {
 	}
 }
 
-// readResponseBody reads the HTTP response body
+// readResponseBody reads and closes the HTTP response body, decompressing it if needed
 func readResponseBody(httpResp *http.Response) ([]byte, error) {
-	defer httpResp.Body.Close()
-
-	// Read all body content
-	// Use 0 capacity if ContentLength is unknown (-1) or negative
-	capacity := max(httpResp.ContentLength, 0)
-	bodyBytes := make([]byte, 0, capacity)
-
-	// Use a temporary buffer to read
-	tmpBuffer := make([]byte, 4096)
-	for {
-		n, err := httpResp.Body.Read(tmpBuffer)
-		if n > 0 {
-			bodyBytes = append(bodyBytes, tmpBuffer[:n]...)
-		}
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
-			}
-			return nil, err
-		}
+	body, err := responseBodyReader(httpResp)
+	if err != nil {
+		return nil, err
 	}
+	defer body.Close()
 
-	return bodyBytes, nil
+	return io.ReadAll(body)
 }
 
 // jsonMarshal is a wrapper around json.Marshal for consistency
@@ -362,6 +543,11 @@ func ExtractRateLimitInfo(headers http.Header) RateLimitInfo {
 		}
 	}
 
+	// HubSpot's window is rolling, so the full budget is available again at most one interval from now
+	if info.Max > 0 {
+		info.WindowResetTime = time.Now().Add(windowInterval(info))
+	}
+
 	if dailyStr := headers.Get("X-HubSpot-RateLimit-Daily"); dailyStr != "" {
 		if daily, err := strconv.Atoi(dailyStr); err == nil {
 			info.DailyLimit = daily
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -564,6 +565,126 @@ func TestHubSpotError(t *testing.T) {
 	})
 }
 
+// TestHubSpotErrorIs tests matching HubSpot errors with the sentinel errors
+func TestHubSpotErrorIs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      *HubSpotError
+		sentinel error
+	}{
+		{"Not found status", &HubSpotError{Status: 404}, ErrNotFound},
+		{"Not found category", &HubSpotError{Status: 400, Category: CategoryObjectNotFound}, ErrNotFound},
+		{"Conflict", &HubSpotError{Status: 409}, ErrConflict},
+		{"Validation category", &HubSpotError{Status: 400, Category: CategoryValidation}, ErrValidation},
+		{"Unprocessable entity", &HubSpotError{Status: 422}, ErrValidation},
+		{"Rate limited", &HubSpotError{Status: 429, Category: CategoryRateLimits}, ErrRateLimited},
+		{"Unauthorized", &HubSpotError{Status: 401, Category: CategoryInvalidAuth}, ErrUnauthorized},
+		{"Missing scopes", &HubSpotError{Status: 403, Category: CategoryMissingScopes}, ErrMissingScopes},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fmt.Errorf("call failed: %w", tc.err)
+			assert.ErrorIs(t, err, tc.sentinel)
+			for _, other := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrRateLimited, ErrUnauthorized, ErrMissingScopes} {
+				if other != tc.sentinel {
+					assert.NotErrorIs(t, err, other)
+				}
+			}
+		})
+	}
+
+	t.Run("Other errors match no sentinel", func(t *testing.T) {
+		err := &HubSpotError{Status: 500}
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, CategoryError("OTHER"))
+	})
+}
+
+// TestConflictExistingID tests parsing the existing record ID of conflict errors
+func TestConflictExistingID(t *testing.T) {
+	t.Run("From message", func(t *testing.T) {
+		err := ParseHubSpotError(409, []byte(`{"status": "error", "message": "Contact already exists. Existing ID: 12345", "category": "CONFLICT"}`), http.Header{})
+		assert.Equal(t, "12345", err.ExistingID())
+
+		id, ok := ConflictExistingID(fmt.Errorf("create failed: %w", err))
+		assert.True(t, ok)
+		assert.Equal(t, "12345", id)
+	})
+
+	t.Run("From error details", func(t *testing.T) {
+		err := ParseHubSpotError(409, []byte(`{
+			"message": "Conflict",
+			"category": "CONFLICT",
+			"errors": [{"message": "Unique value already in use. Existing ID: 678", "in": "sku"}]
+		}`), http.Header{})
+		assert.Equal(t, "678", err.ExistingID())
+		assert.Equal(t, "sku", err.Field())
+	})
+
+	t.Run("Not a conflict", func(t *testing.T) {
+		_, ok := ConflictExistingID(&HubSpotError{Status: 400, Message: "Existing ID: 1"})
+		assert.False(t, ok)
+
+		_, ok = ConflictExistingID(&HubSpotError{Status: 409, Message: "Conflict"})
+		assert.False(t, ok)
+	})
+}
+
+// TestErrorOrNil tests converting a possibly nil HubSpotError to an error
+func TestErrorOrNil(t *testing.T) {
+	assert.Nil(t, ErrorOrNil(nil))
+
+	original := &HubSpotError{Status: 404}
+	assert.Same(t, original, ErrorOrNil(original))
+}
+
+// TestCreateOrResolve tests resolving the existing record when a create conflicts
+func TestCreateOrResolve(t *testing.T) {
+	conflict := ParseHubSpotError(409, []byte(`{"status": "error", "message": "Contact already exists. Existing ID: 151", "category": "CONFLICT"}`), http.Header{})
+
+	tests := []struct {
+		name       string
+		createErr  error
+		resolveErr error
+		record     string
+		created    bool
+		err        error
+	}{
+		{"Created", nil, nil, "new", true, nil},
+		{"Conflict resolves the existing record", fmt.Errorf("create failed: %w", conflict), nil, "existing 151", false, nil},
+		{"Conflict without an existing ID", &HubSpotError{Status: 409, Message: "Conflict"}, nil, "", false, ErrConflict},
+		{"Other errors", &HubSpotError{Status: 400, Category: CategoryValidation}, nil, "", false, ErrValidation},
+		{"Resolve error", conflict, &HubSpotError{Status: 404}, "", false, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := func() (string, error) {
+				if tt.createErr != nil {
+					return "partial", tt.createErr
+				}
+				return "new", nil
+			}
+			resolve := func(existingID string) (string, error) {
+				if tt.resolveErr != nil {
+					return "partial", tt.resolveErr
+				}
+				return "existing " + existingID, nil
+			}
+
+			record, created, err := CreateOrResolve(create, resolve)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.record, record)
+			assert.Equal(t, tt.created, created)
+		})
+	}
+}
+
 // TestParseHubSpotError tests error parsing
 func TestParseHubSpotError(t *testing.T) {
 	t.Run("Parse complete error", func(t *testing.T) {
@@ -587,6 +708,62 @@ func TestParseHubSpotError(t *testing.T) {
 		assert.False(t, err.IsRetryable) // 400 is not retryable
 	})
 
+	t.Run("Parse error details", func(t *testing.T) {
+		body := []byte(`{
+			"status": "error",
+			"message": "Property values were not valid",
+			"category": "VALIDATION_ERROR",
+			"subCategory": "PROPERTY_VALIDATION",
+			"context": {"propertyName": ["email"]},
+			"errors": [
+				{
+					"message": "Email address is invalid",
+					"code": "INVALID_EMAIL",
+					"in": "email",
+					"context": {"propertyName": ["email"], "value": "not-an-email"},
+					"subCategory": {"type": "INVALID"}
+				}
+			]
+		}`)
+
+		err := ParseHubSpotError(400, body, http.Header{})
+		assert.Equal(t, "PROPERTY_VALIDATION", err.SubCategory)
+		assert.Equal(t, map[string][]string{"propertyName": {"email"}}, err.Context)
+		require.Len(t, err.Errors, 1)
+		assert.Equal(t, ErrorDetail{
+			Message:     "Email address is invalid",
+			Code:        "INVALID_EMAIL",
+			In:          "email",
+			Context:     map[string][]string{"propertyName": {"email"}, "value": {"not-an-email"}},
+			SubCategory: `{"type": "INVALID"}`,
+		}, err.Errors[0])
+		assert.Equal(t, "email", err.Field())
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Lenient context", func(t *testing.T) {
+		err := ParseHubSpotError(400, []byte(`{
+			"message": "Property values were not valid",
+			"category": "VALIDATION_ERROR",
+			"context": {"propertyName": "email", "ids": ["1", "2"], "limit": 100}
+		}`), http.Header{})
+		assert.Equal(t, "Property values were not valid", err.Message)
+		assert.Equal(t, CategoryValidation, err.Category)
+		assert.Equal(t, map[string][]string{"propertyName": {"email"}, "ids": {"1", "2"}, "limit": {"100"}}, err.Context)
+
+		err = ParseHubSpotError(400, []byte(`{"message": "Bad request", "context": "unexpected"}`), http.Header{})
+		assert.Equal(t, "Bad request", err.Message)
+		assert.Nil(t, err.Context)
+	})
+
+	t.Run("Field from context", func(t *testing.T) {
+		err := ParseHubSpotError(400, []byte(`{"errors": [{"message": "bad", "context": {"propertyName": ["phone"]}}]}`), http.Header{})
+		assert.Equal(t, "phone", err.Field())
+
+		err = ParseHubSpotError(400, []byte(`{"message": "bad"}`), http.Header{})
+		assert.Empty(t, err.Field())
+	})
+
 	t.Run("Parse with Retry-After header (seconds)", func(t *testing.T) {
 		body := []byte(`{"status": "error", "message": "Rate limited"}`)
 		headers := http.Header{}
@@ -667,6 +844,17 @@ func TestExtractRateLimitInfo(t *testing.T) {
 		assert.Equal(t, 0, info.Max)
 		assert.Equal(t, 0, info.Remaining)
 		assert.Equal(t, 10000, info.IntervalMs) // Default value
+		assert.True(t, info.WindowResetTime.IsZero())
+	})
+
+	t.Run("Extract window reset time", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-HubSpot-RateLimit-Max", "190")
+		headers.Set("X-HubSpot-RateLimit-Remaining", "150")
+		headers.Set("X-HubSpot-RateLimit-Interval-Milliseconds", "10000")
+
+		info := ExtractRateLimitInfo(headers)
+		assert.WithinDuration(t, time.Now().Add(10*time.Second), info.WindowResetTime, time.Second)
 	})
 
 	t.Run("Extract with invalid values", func(t *testing.T) {
@@ -0,0 +1,50 @@
+package client
+
+import "encoding/json"
+
+// CollectionResponse is the envelope of HubSpot list and search responses, shared by the service packages
+// (e.g. companies.ListCompaniesResponse). A page without matches decodes to empty, non-nil Results and is not an
+// error. Total is only reported by search endpoints.
+type CollectionResponse[T any] struct {
+	Total   int    `json:"total"`
+	Results []T    `json:"results"`
+	Paging  Paging `json:"paging,omitzero"`
+}
+
+// collectionFields has the fields of CollectionResponse without its UnmarshalJSON method
+type collectionFields[T any] CollectionResponse[T]
+
+// UnmarshalJSON decodes the envelope, leaving Results empty rather than nil when the response has no results
+func (r *CollectionResponse[T]) UnmarshalJSON(data []byte) error {
+	var fields collectionFields[T]
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields.Results == nil {
+		fields.Results = []T{}
+	}
+
+	*r = CollectionResponse[T](fields)
+	return nil
+}
+
+// Paging is the paging of a list or search response. Next.After is empty on the last page.
+type Paging struct {
+	Next PagingLink `json:"next,omitzero"`
+	Prev PagingLink `json:"prev,omitzero"`
+}
+
+// PagingLink points to an adjacent page
+type PagingLink struct {
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+	Link   string `json:"link,omitempty"`
+}
+
+// NextAfter returns the cursor of the next page, or "" on the last page
+func (p *Paging) NextAfter() string {
+	if p == nil {
+		return ""
+	}
+	return p.Next.After
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCollectionResponse tests decoding the shared list and search envelope
+func TestCollectionResponse(t *testing.T) {
+	type record struct {
+		ID string `json:"id"`
+	}
+
+	t.Run("Decodes a page", func(t *testing.T) {
+		var resp CollectionResponse[record]
+		err := json.Unmarshal([]byte(`{
+			"total": 3,
+			"results": [{"id": "1"}, {"id": "2"}],
+			"paging": {"next": {"after": "2", "link": "https://api.hubapi.com/next"}, "prev": {"before": "1"}}
+		}`), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, resp.Total)
+		assert.Equal(t, []record{{ID: "1"}, {ID: "2"}}, resp.Results)
+		assert.Equal(t, "2", resp.Paging.NextAfter())
+		assert.Equal(t, "1", resp.Paging.Prev.Before)
+	})
+
+	t.Run("Empty page", func(t *testing.T) {
+		for _, body := range []string{`{"total": 0, "results": []}`, `{}`} {
+			var resp CollectionResponse[record]
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			assert.Equal(t, 0, resp.Total)
+			assert.NotNil(t, resp.Results)
+			assert.Empty(t, resp.Results)
+			assert.Empty(t, resp.Paging.NextAfter())
+		}
+	})
+
+	t.Run("Malformed body", func(t *testing.T) {
+		var resp CollectionResponse[record]
+		assert.Error(t, json.Unmarshal([]byte(`{"results": {}}`), &resp))
+	})
+
+	t.Run("Nil paging", func(t *testing.T) {
+		var paging *Paging
+		assert.Empty(t, paging.NextAfter())
+	})
+
+	t.Run("Encodes without empty paging", func(t *testing.T) {
+		body, err := json.Marshal(CollectionResponse[record]{Results: []record{{ID: "1"}}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"total": 0, "results": [{"id": "1"}]}`, string(body))
+	})
+}
@@ -1,18 +1,36 @@
 package client
 
 import (
+	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 )
 
 // Config holds all configuration for the HubSpot API client
 type Config struct {
 	AccessToken string
+	TokenSource TokenSource // Takes precedence over AccessToken when set
 	BaseURL     string
 	Timeout     time.Duration
+	Transport   http.RoundTripper // Defaults to http.DefaultTransport
 	RateLimit   RateLimitConfig
 	Retry       RetryConfig
 	Logger      *slog.Logger
+	Logging     LogConfig
+	Compression bool // Requests gzip encoded responses
+
+	// CircuitBreaker enables the circuit breaker stage when set
+	CircuitBreaker *CircuitBreakerConfig
+
+	// Instrumentation receives an event for every call and attempt
+	Instrumentation Instrumentation
+
+	// Cache enables the response cache stage when set
+	Cache *CacheConfig
+
+	// User middleware inserted into the handler chain
+	middleware []stagedMiddleware
 }
 
 // RateLimitConfig configures rate limiting behavior
@@ -20,6 +38,26 @@ type RateLimitConfig struct {
 	MaxBurst   int
 	DailyLimit int
 	Enabled    bool
+
+	// Limiter replaces the in-process burst limiter when set
+	Limiter Limiter
+
+	// Store shares the burst budget with other processes using the same store and key
+	Store    RateLimitStore
+	StoreKey string
+
+	// Policies limit endpoint classes or resource types in addition to the general budget
+	Policies map[string]RateLimitPolicy
+
+	// Location is the account's time zone, whose midnight resets the daily quota. Defaults to UTC.
+	Location *time.Location
+
+	// OnQuotaThreshold is called when the daily usage crosses each of QuotaThresholds, given as fractions of the daily limit
+	QuotaThresholds  []float64
+	OnQuotaThreshold QuotaThresholdFunc
+
+	// Priority schedules waiting requests by priority class, bounds concurrency and reserves daily quota
+	Priority PriorityConfig
 }
 
 // RetryConfig configures retry behavior
@@ -28,6 +66,12 @@ type RetryConfig struct {
 	InitialBackoff time.Duration
 	MaxBackoff     time.Duration
 	Enabled        bool
+
+	// MaxElapsedTime bounds the total time spent on a request including backoff. Zero means no limit.
+	MaxElapsedTime time.Duration
+
+	// Policy decides which failures are retried, defaults to a DefaultRetryPolicy using the backoff above
+	Policy RetryPolicy
 }
 
 // Option is a functional option for configuring the Client
@@ -42,6 +86,7 @@ func NewConfig() *Config {
 			MaxBurst:   100,
 			DailyLimit: 250000,
 			Enabled:    true,
+			Policies:   DefaultRateLimitPolicies(),
 		},
 		Retry: RetryConfig{
 			MaxAttempts:    3,
@@ -49,7 +94,9 @@ func NewConfig() *Config {
 			MaxBackoff:     30 * time.Second,
 			Enabled:        true,
 		},
-		Logger: slog.Default(),
+		Logger:      slog.Default(),
+		Logging:     DefaultLogConfig(),
+		Compression: true,
 	}
 }
 
@@ -61,6 +108,17 @@ func WithAccessToken(token string) Option {
 	}
 }
 
+// WithTokenSource sets the TokenSource used to authenticate requests, e.g. an OAuthTokenSource
+func WithTokenSource(source TokenSource) Option {
+	return func(cfg *Config) error {
+		if source == nil {
+			return fmt.Errorf("token source cannot be nil")
+		}
+		cfg.TokenSource = source
+		return nil
+	}
+}
+
 // WithBaseURL sets the API base URL (useful for testing)
 func WithBaseURL(url string) Option {
 	return func(cfg *Config) error {
@@ -77,6 +135,25 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithCompression enables or disables requesting gzip encoded responses
+func WithCompression(enabled bool) Option {
+	return func(cfg *Config) error {
+		cfg.Compression = enabled
+		return nil
+	}
+}
+
+// WithTransport sets the transport used for HTTP requests, e.g. a Recorder in tests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *Config) error {
+		if transport == nil {
+			return fmt.Errorf("transport cannot be nil")
+		}
+		cfg.Transport = transport
+		return nil
+	}
+}
+
 // WithRateLimitMaxBurst sets the maximum burst for rate limiting
 func WithRateLimitMaxBurst(burst int) Option {
 	return func(cfg *Config) error {
@@ -101,6 +178,78 @@ func WithRateLimitEnabled(enabled bool) Option {
 	}
 }
 
+// WithRateLimiter replaces the in-process burst limiter with a custom Limiter
+func WithRateLimiter(limiter Limiter) Option {
+	return func(cfg *Config) error {
+		if limiter == nil {
+			return fmt.Errorf("rate limiter cannot be nil")
+		}
+		cfg.RateLimit.Limiter = limiter
+		return nil
+	}
+}
+
+// WithRateLimitStore shares the burst budget through a RateLimitStore with every client using the same store and key.
+// The key should identify the budget being shared, e.g. the portal ID or private app.
+func WithRateLimitStore(store RateLimitStore, key string) Option {
+	return func(cfg *Config) error {
+		if store == nil {
+			return fmt.Errorf("rate limit store cannot be nil")
+		}
+		if key == "" {
+			return fmt.Errorf("rate limit store key cannot be empty")
+		}
+		cfg.RateLimit.Store = store
+		cfg.RateLimit.StoreKey = key
+		return nil
+	}
+}
+
+// WithRateLimitPolicy sets the rate limit policy for an endpoint class (e.g. SearchClass) or resource type
+func WithRateLimitPolicy(key string, policy RateLimitPolicy) Option {
+	return func(cfg *Config) error {
+		if key == "" {
+			return fmt.Errorf("rate limit policy key cannot be empty")
+		}
+		if cfg.RateLimit.Policies == nil {
+			cfg.RateLimit.Policies = make(map[string]RateLimitPolicy)
+		}
+		cfg.RateLimit.Policies[key] = policy
+		return nil
+	}
+}
+
+// WithAccountTimeZone sets the account's time zone as reported by the account info API (e.g. "us/eastern"),
+// so the daily quota resets at the account's midnight
+func WithAccountTimeZone(name string) Option {
+	return func(cfg *Config) error {
+		loc, err := LoadAccountLocation(name)
+		if err != nil {
+			return err
+		}
+		cfg.RateLimit.Location = loc
+		return nil
+	}
+}
+
+// WithQuotaThresholds calls fn once per day when the daily usage crosses each threshold,
+// given as fractions of the daily limit (e.g. 0.8 and 0.95)
+func WithQuotaThresholds(fn QuotaThresholdFunc, thresholds ...float64) Option {
+	return func(cfg *Config) error {
+		if fn == nil {
+			return fmt.Errorf("quota threshold func cannot be nil")
+		}
+		for _, threshold := range thresholds {
+			if threshold <= 0 || threshold > 1 {
+				return fmt.Errorf("quota threshold %v must be between 0 and 1", threshold)
+			}
+		}
+		cfg.RateLimit.QuotaThresholds = thresholds
+		cfg.RateLimit.OnQuotaThreshold = fn
+		return nil
+	}
+}
+
 // WithRetryMaxAttempts sets the maximum number of retry attempts
 func WithRetryMaxAttempts(attempts int) Option {
 	return func(cfg *Config) error {
@@ -126,6 +275,103 @@ func WithRetryBackoff(initial, max time.Duration) Option {
 	}
 }
 
+// WithRetryMaxElapsedTime bounds the total time spent retrying a request, in addition to the max attempts
+func WithRetryMaxElapsedTime(maxElapsed time.Duration) Option {
+	return func(cfg *Config) error {
+		if maxElapsed < 0 {
+			return fmt.Errorf("retry max elapsed time cannot be negative")
+		}
+		cfg.Retry.MaxElapsedTime = maxElapsed
+		return nil
+	}
+}
+
+// WithPriorityScheduling hands out rate limit tokens to waiting requests in weighted fair order of their priority
+// classes, optionally bounding the requests in flight and reserving daily quota for PriorityHigh requests
+func WithPriorityScheduling(priority PriorityConfig) Option {
+	return func(cfg *Config) error {
+		for class, weight := range priority.Weights {
+			if weight <= 0 {
+				return fmt.Errorf("weight of priority %s must be positive", class)
+			}
+		}
+		if priority.MaxInFlight < 0 {
+			return fmt.Errorf("max in-flight requests cannot be negative")
+		}
+		if priority.HighPriorityReserve < 0 || priority.HighPriorityReserve >= 1 {
+			return fmt.Errorf("high priority reserve must be between 0 and 1, got %v", priority.HighPriorityReserve)
+		}
+		if len(priority.Weights) == 0 {
+			priority.Weights = DefaultPriorityWeights()
+		}
+		cfg.RateLimit.Priority = priority
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces the DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *Config) error {
+		if policy == nil {
+			return fmt.Errorf("retry policy cannot be nil")
+		}
+		cfg.Retry.Policy = policy
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker that fails requests fast while HubSpot is failing
+func WithCircuitBreaker(breaker CircuitBreakerConfig) Option {
+	return func(cfg *Config) error {
+		if breaker.FailureThreshold < 0 || breaker.OpenTimeout < 0 || breaker.HalfOpenProbes < 0 {
+			return fmt.Errorf("circuit breaker thresholds cannot be negative")
+		}
+		cfg.CircuitBreaker = &breaker
+		return nil
+	}
+}
+
+// WithCache caches GET responses of the resource types in cfg.TTLs, e.g. schemas that rarely change
+func WithCache(cache CacheConfig) Option {
+	return func(cfg *Config) error {
+		if len(cache.TTLs) == 0 {
+			return fmt.Errorf("cache TTLs cannot be empty")
+		}
+		for resourceType, ttl := range cache.TTLs {
+			if ttl <= 0 {
+				return fmt.Errorf("cache TTL for %s must be positive", resourceType)
+			}
+		}
+		if cache.MaxEntries < 0 {
+			return fmt.Errorf("cache max entries cannot be negative")
+		}
+		cfg.Cache = &cache
+		return nil
+	}
+}
+
+// WithInstrumentation reports every call and attempt to the given instrumentations, e.g. PrometheusMetrics
+func WithInstrumentation(instrumentation ...Instrumentation) Option {
+	return func(cfg *Config) error {
+		for _, inst := range instrumentation {
+			if inst == nil {
+				return fmt.Errorf("instrumentation cannot be nil")
+			}
+		}
+		var all multiInstrumentation
+		if cfg.Instrumentation != nil {
+			all = append(all, cfg.Instrumentation)
+		}
+		all = append(all, instrumentation...)
+		if len(all) == 1 {
+			cfg.Instrumentation = all[0]
+		} else {
+			cfg.Instrumentation = all
+		}
+		return nil
+	}
+}
+
 // WithLogger sets the logger for the client
 func WithLogger(logger *slog.Logger) Option {
 	return func(cfg *Config) error {
@@ -133,3 +379,52 @@ func WithLogger(logger *slog.Logger) Option {
 		return nil
 	}
 }
+
+// WithLogConfig replaces the redaction, body and level settings used when logging requests and responses.
+// Start from DefaultLogConfig to keep the default redaction; credential headers are redacted either way.
+func WithLogConfig(logging LogConfig) Option {
+	return func(cfg *Config) error {
+		if logging.MaxBodyBytes < 0 {
+			return fmt.Errorf("max logged body bytes cannot be negative")
+		}
+		if logging.BodySampleRate < 0 || logging.BodySampleRate > 1 {
+			return fmt.Errorf("body sample rate %v must be between 0 and 1", logging.BodySampleRate)
+		}
+		cfg.Logging = logging
+		return nil
+	}
+}
+
+// WithResourceLogLevel sets the minimum level logged for requests of a resource type
+func WithResourceLogLevel(resourceType string, level slog.Level) Option {
+	return func(cfg *Config) error {
+		if cfg.Logging.ResourceLevels == nil {
+			cfg.Logging.ResourceLevels = make(map[string]slog.Level)
+		}
+		cfg.Logging.ResourceLevels[resourceType] = level
+		return nil
+	}
+}
+
+// WithMiddleware adds user middleware to the outermost stage of the handler chain.
+// Middleware run in the order they are given.
+func WithMiddleware(middleware ...Middleware) Option {
+	return WithStageMiddleware(StageOutermost, middleware...)
+}
+
+// WithStageMiddleware adds user middleware at the given stage of the handler chain.
+// Middleware run in the order they are given.
+func WithStageMiddleware(stage MiddlewareStage, middleware ...Middleware) Option {
+	return func(cfg *Config) error {
+		if !stage.valid() {
+			return fmt.Errorf("invalid middleware stage: %s", stage)
+		}
+		for _, mw := range middleware {
+			if mw == nil {
+				return fmt.Errorf("nil middleware for stage %s", stage)
+			}
+			cfg.middleware = append(cfg.middleware, stagedMiddleware{stage: stage, middleware: mw})
+		}
+		return nil
+	}
+}
@@ -2,20 +2,56 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
 	"time"
 )
 
+// Sentinel errors matched with errors.Is by HubSpotError and the error types of the service packages
+var (
+	ErrNotFound      = errors.New("hubspot: not found")
+	ErrConflict      = errors.New("hubspot: conflict")
+	ErrValidation    = errors.New("hubspot: validation failed")
+	ErrRateLimited   = errors.New("hubspot: rate limited")
+	ErrUnauthorized  = errors.New("hubspot: unauthorized")
+	ErrMissingScopes = errors.New("hubspot: missing scopes")
+)
+
+// HubSpot error categories
+const (
+	CategoryValidation       = "VALIDATION_ERROR"
+	CategoryObjectNotFound   = "OBJECT_NOT_FOUND"
+	CategoryConflict         = "CONFLICT"
+	CategoryRateLimits       = "RATE_LIMITS"
+	CategoryMissingScopes    = "MISSING_SCOPES"
+	CategoryInvalidAuth      = "INVALID_AUTHENTICATION"
+	CategoryExpiredAuth      = "EXPIRED_AUTHENTICATION"
+	CategoryPermissionDenied = "PERMISSION_DENIED"
+)
+
+// ErrorDetail is an entry of the errors array of a HubSpot error response
+type ErrorDetail struct {
+	Message     string              `json:"message"`
+	Code        string              `json:"code"`
+	In          string              `json:"in"` // The property or parameter the error refers to
+	Context     map[string][]string `json:"context"`
+	SubCategory string              `json:"subCategory"`
+}
+
 // HubSpotError stores the information returned from Hubspot Errors. Implements the error interface.
 type HubSpotError struct {
 	Status        int
 	Message       string
 	ErrorType     string // "RATE_LIMIT", "VALIDATION_ERROR", etc.
 	Category      string
+	SubCategory   string
 	PolicyName    string // "DAILY" or "TEN_SECONDLY_ROLLING"
 	CorrelationID string
+	Context       map[string][]string
+	Errors        []ErrorDetail
 
 	// Used to deteremine if request should retry
 	IsRetryable bool
@@ -36,6 +72,120 @@ func (e *HubSpotError) Error() string {
 	return fmt.Sprintf("HubSpot API error: status %d", e.Status)
 }
 
+// Is matches the sentinel errors by status and category
+func (e *HubSpotError) Is(target error) bool {
+	if target != nil && CategoryError(e.Category) == target {
+		return true
+	}
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrConflict:
+		return e.Status == http.StatusConflict
+	case ErrValidation:
+		return e.Status == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	}
+	return false
+}
+
+// existingIDPattern matches the ID HubSpot names in the message of a conflict error, e.g. "Contact already exists. Existing ID: 12345"
+var existingIDPattern = regexp.MustCompile(`Existing ID: ?(\d+)`)
+
+// ExistingID returns the ID of the existing record a conflict error refers to, or "" if HubSpot didn't name one
+func (e *HubSpotError) ExistingID() string {
+	messages := []string{e.Message}
+	for _, detail := range e.Errors {
+		messages = append(messages, detail.Message)
+	}
+	for _, message := range messages {
+		if match := existingIDPattern.FindStringSubmatch(message); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// ConflictExistingID returns the ID of the existing record if err is a HubSpot conflict error that names one
+func ConflictExistingID(err error) (string, bool) {
+	var hubspotErr *HubSpotError
+	if !errors.As(err, &hubspotErr) || !errors.Is(hubspotErr, ErrConflict) {
+		return "", false
+	}
+	id := hubspotErr.ExistingID()
+	return id, id != ""
+}
+
+// ErrorOrNil returns original as an error, or a nil error rather than a typed nil if original is nil, e.g. for the
+// Unwrap methods of errors wrapping a HubSpotError
+func ErrorOrNil(original *HubSpotError) error {
+	if original == nil {
+		return nil
+	}
+	return original
+}
+
+// CreateOrResolve calls create, and if it fails with a conflict error naming an existing record, resolves the
+// existing record by its ID instead, e.g. by reading or updating it. created reports whether create succeeded.
+func CreateOrResolve[T any](create func() (T, error), resolve func(existingID string) (T, error)) (record T, created bool, err error) {
+	record, err = create()
+	if err == nil {
+		return record, true, nil
+	}
+
+	existingID, ok := ConflictExistingID(err)
+	if !ok {
+		var zero T
+		return zero, false, err
+	}
+
+	record, err = resolve(existingID)
+	if err != nil {
+		var zero T
+		return zero, false, err
+	}
+	return record, false, nil
+}
+
+// CategoryError returns the sentinel error of a HubSpot error category, or nil if the category has none.
+// Errors that only carry a category, like the entries of a batch response, use it to implement Is.
+func CategoryError(category string) error {
+	switch category {
+	case CategoryObjectNotFound:
+		return ErrNotFound
+	case CategoryConflict:
+		return ErrConflict
+	case CategoryValidation:
+		return ErrValidation
+	case CategoryRateLimits:
+		return ErrRateLimited
+	case CategoryInvalidAuth, CategoryExpiredAuth:
+		return ErrUnauthorized
+	case CategoryMissingScopes:
+		return ErrMissingScopes
+	}
+	return nil
+}
+
+// Field returns the property or parameter the first error detail refers to, or "" if the details don't name one
+func (e *HubSpotError) Field() string {
+	for _, detail := range e.Errors {
+		if detail.In != "" {
+			return detail.In
+		}
+		if names := detail.Context["propertyName"]; len(names) > 0 {
+			return names[0]
+		}
+	}
+	if names := e.Context["propertyName"]; len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
 // ParseHubSpotError parses a response into a HubSpotError
 func ParseHubSpotError(statusCode int, body []byte, headers http.Header) *HubSpotError {
 	err := &HubSpotError{
@@ -56,20 +206,28 @@ func ParseHubSpotError(statusCode int, body []byte, headers http.Header) *HubSpo
 
 	// Try to unmarshal HubSpot error format
 	var hubspotResp struct {
-		Status        string `json:"status"`
-		Message       string `json:"message"`
-		ErrorType     string `json:"errorType"`
-		Category      string `json:"category"`
-		PolicyName    string `json:"policyName"`
-		CorrelationID string `json:"correlationId"`
+		Status        string            `json:"status"`
+		Message       string            `json:"message"`
+		ErrorType     string            `json:"errorType"`
+		Category      string            `json:"category"`
+		SubCategory   json.RawMessage   `json:"subCategory"`
+		PolicyName    string            `json:"policyName"`
+		CorrelationID string            `json:"correlationId"`
+		Context       json.RawMessage   `json:"context"`
+		Errors        []json.RawMessage `json:"errors"`
 	}
 
 	if unmarshalErr := json.Unmarshal(body, &hubspotResp); unmarshalErr == nil {
 		err.Message = hubspotResp.Message
 		err.ErrorType = hubspotResp.ErrorType
 		err.Category = hubspotResp.Category
+		err.SubCategory = subCategoryString(hubspotResp.SubCategory)
 		err.PolicyName = hubspotResp.PolicyName
 		err.CorrelationID = hubspotResp.CorrelationID
+		err.Context = parseErrorContext(hubspotResp.Context)
+		for _, raw := range hubspotResp.Errors {
+			err.Errors = append(err.Errors, parseErrorDetail(raw))
+		}
 	}
 
 	// Determine if retryable
@@ -78,6 +236,63 @@ func ParseHubSpotError(statusCode int, body []byte, headers http.Header) *HubSpo
 	return err
 }
 
+// parseErrorDetail parses an entry of the errors array, tolerating context values and sub-categories of other shapes
+func parseErrorDetail(raw json.RawMessage) ErrorDetail {
+	var detail struct {
+		Message     string          `json:"message"`
+		Code        string          `json:"code"`
+		In          string          `json:"in"`
+		Context     json.RawMessage `json:"context"`
+		SubCategory json.RawMessage `json:"subCategory"`
+	}
+	if err := json.Unmarshal(raw, &detail); err != nil {
+		return ErrorDetail{}
+	}
+
+	return ErrorDetail{
+		Message:     detail.Message,
+		Code:        detail.Code,
+		In:          detail.In,
+		Context:     parseErrorContext(detail.Context),
+		SubCategory: subCategoryString(detail.SubCategory),
+	}
+}
+
+// parseErrorContext parses the context of an error, keeping values that aren't string arrays as single strings.
+// A context that isn't an object is dropped.
+func parseErrorContext(raw json.RawMessage) map[string][]string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || len(fields) == 0 {
+		return nil
+	}
+
+	parsed := make(map[string][]string, len(fields))
+	for key, value := range fields {
+		var values []string
+		if err := json.Unmarshal(value, &values); err != nil {
+			var single string
+			if err := json.Unmarshal(value, &single); err != nil {
+				single = string(value)
+			}
+			values = []string{single}
+		}
+		parsed[key] = values
+	}
+	return parsed
+}
+
+// subCategoryString returns a sub-category, which HubSpot sends as a string or occasionally an object, as a string
+func subCategoryString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
 // isRetryableStatus determines if an HTTP status should be retried
 func isRetryableStatus(statusCode int, policyName string) bool {
 	switch statusCode {
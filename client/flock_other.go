@@ -0,0 +1,21 @@
+//go:build !unix
+
+package client
+
+import (
+	"errors"
+	"os"
+)
+
+// errFileLockUnsupported is returned by FileRateLimitStore on platforms without file locks
+var errFileLockUnsupported = errors.New("file locks are not supported on this platform")
+
+// lockFile is not supported on this platform
+func lockFile(file *os.File) error {
+	return errFileLockUnsupported
+}
+
+// unlockFile is not supported on this platform
+func unlockFile(file *os.File) error {
+	return errFileLockUnsupported
+}
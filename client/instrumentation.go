@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// correlationIDHeader is the response header carrying HubSpot's correlation ID
+const correlationIDHeader = "X-HubSpot-Correlation-Id"
+
+// Instrumentation receives events for every call to Client.Do and every HTTP attempt made for it
+//
+// The contexts returned by the Start methods are used for the rest of the call or attempt,
+// so implementations can carry a tracing span in them. Implementations must be safe for concurrent use.
+// Embed NopInstrumentation to only implement some of the methods.
+type Instrumentation interface {
+	StartCall(req *Request) context.Context
+	EndCall(ctx context.Context, event CallEvent)
+	StartAttempt(req *Request) context.Context
+	EndAttempt(ctx context.Context, event AttemptEvent)
+}
+
+// RequestEvent holds the fields shared by CallEvent and AttemptEvent
+type RequestEvent struct {
+	Method       string
+	PathTemplate string
+	ResourceType string
+
+	// StatusCode is zero if no response was received
+	StatusCode    int
+	CorrelationID string
+	Err           error
+
+	Latency       time.Duration
+	RequestBytes  int
+	ResponseBytes int
+}
+
+// CallEvent describes a completed call to Client.Do, including all of its attempts
+type CallEvent struct {
+	RequestEvent
+
+	// RetryCount is the number of attempts after the first
+	RetryCount int
+
+	// RateLimitWait is the time spent waiting for the rate limiters
+	RateLimitWait time.Duration
+}
+
+// AttemptEvent describes a single HTTP attempt
+type AttemptEvent struct {
+	RequestEvent
+
+	// RetryCount is zero for the first attempt
+	RetryCount int
+
+	// RateLimit is the rate limit state reported by the response
+	RateLimit RateLimitInfo
+}
+
+// NopInstrumentation implements Instrumentation without doing anything
+type NopInstrumentation struct{}
+
+// StartCall implements Instrumentation
+func (NopInstrumentation) StartCall(req *Request) context.Context { return req.Context }
+
+// EndCall implements Instrumentation
+func (NopInstrumentation) EndCall(context.Context, CallEvent) {}
+
+// StartAttempt implements Instrumentation
+func (NopInstrumentation) StartAttempt(req *Request) context.Context { return req.Context }
+
+// EndAttempt implements Instrumentation
+func (NopInstrumentation) EndAttempt(context.Context, AttemptEvent) {}
+
+// multiInstrumentation fans events out to several instrumentations, threading the context through them
+type multiInstrumentation []Instrumentation
+
+func (m multiInstrumentation) StartCall(req *Request) context.Context {
+	for _, inst := range m {
+		req.Context = inst.StartCall(req)
+	}
+	return req.Context
+}
+
+func (m multiInstrumentation) EndCall(ctx context.Context, event CallEvent) {
+	for _, inst := range m {
+		inst.EndCall(ctx, event)
+	}
+}
+
+func (m multiInstrumentation) StartAttempt(req *Request) context.Context {
+	for _, inst := range m {
+		req.Context = inst.StartAttempt(req)
+	}
+	return req.Context
+}
+
+func (m multiInstrumentation) EndAttempt(ctx context.Context, event AttemptEvent) {
+	for _, inst := range m {
+		inst.EndAttempt(ctx, event)
+	}
+}
+
+func (m multiInstrumentation) ObserveCache(ctx context.Context, event CacheEvent) {
+	for _, inst := range m {
+		if observer, ok := inst.(CacheObserver); ok {
+			observer.ObserveCache(ctx, event)
+		}
+	}
+}
+
+// newRequestEvent describes the outcome of a request
+func newRequestEvent(req *Request, resp *Response, err error, latency time.Duration) RequestEvent {
+	event := RequestEvent{
+		Method:       req.Method,
+		PathTemplate: PathTemplate(req.Path),
+		ResourceType: req.ResourceType,
+		Err:          err,
+		Latency:      latency,
+		RequestBytes: req.bodySize,
+	}
+
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+		event.ResponseBytes = len(resp.Body)
+		event.CorrelationID = resp.Headers.Get(correlationIDHeader)
+	}
+
+	var hubspotErr *HubSpotError
+	if event.CorrelationID == "" && errors.As(err, &hubspotErr) {
+		event.CorrelationID = hubspotErr.CorrelationID
+	}
+
+	return event
+}
+
+// wrapCallInstrumentation reports every call to Do
+func (c *Client) wrapCallInstrumentation(next Handler) Handler {
+	if c.instrumentation == nil {
+		return next
+	}
+
+	return func(req *Request) (*Response, error) {
+		req.rateLimitWait = 0
+		ctx := c.instrumentation.StartCall(req)
+		req.Context = ctx
+		start := time.Now()
+
+		resp, err := next(req)
+
+		c.instrumentation.EndCall(ctx, CallEvent{
+			RequestEvent:  newRequestEvent(req, resp, err, time.Since(start)),
+			RetryCount:    req.RetryCount,
+			RateLimitWait: req.rateLimitWait,
+		})
+
+		return resp, err
+	}
+}
+
+// wrapAttemptInstrumentation reports every HTTP attempt, including those failed fast by the circuit breaker
+func (c *Client) wrapAttemptInstrumentation(next Handler) Handler {
+	if c.instrumentation == nil {
+		return next
+	}
+
+	return func(req *Request) (*Response, error) {
+		callCtx := req.Context
+		ctx := c.instrumentation.StartAttempt(req)
+		req.Context = ctx
+		start := time.Now()
+
+		resp, err := next(req)
+
+		event := AttemptEvent{
+			RequestEvent: newRequestEvent(req, resp, err, time.Since(start)),
+			RetryCount:   req.RetryCount,
+		}
+		if resp != nil {
+			event.RateLimit = resp.RateLimit
+		}
+		c.instrumentation.EndAttempt(ctx, event)
+		req.Context = callCtx
+
+		return resp, err
+	}
+}
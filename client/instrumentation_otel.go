@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// OTelTracer is the part of an OpenTelemetry tracer used by OTelInstrumentation
+//
+// It keeps the SDK free of the OpenTelemetry modules; a thin wrapper around trace.Tracer satisfies it:
+//
+//	func (t tracer) Start(ctx context.Context, name string, attrs map[string]any) (context.Context, client.OTelSpan) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(toKeyValues(attrs)...))
+//		return ctx, spanWrapper{span}
+//	}
+type OTelTracer interface {
+	Start(ctx context.Context, spanName string, attributes map[string]any) (context.Context, OTelSpan)
+}
+
+// OTelSpan is the part of an OpenTelemetry span used by OTelInstrumentation
+type OTelSpan interface {
+	SetAttributes(attributes map[string]any)
+	// RecordError records err and marks the span as failed
+	RecordError(err error)
+	End()
+}
+
+// Attribute keys follow the OpenTelemetry HTTP client semantic conventions where one exists
+const (
+	AttrHTTPMethod       = "http.request.method"
+	AttrHTTPStatusCode   = "http.response.status_code"
+	AttrHTTPResendCount  = "http.request.resend_count"
+	AttrHTTPRequestSize  = "http.request.body.size"
+	AttrHTTPResponseSize = "http.response.body.size"
+	AttrURLTemplate      = "url.template"
+	AttrErrorType        = "error.type"
+
+	AttrResourceType    = "hubspot.resource_type"
+	AttrCorrelationID   = "hubspot.correlation_id"
+	AttrRateLimitWaitMs = "hubspot.rate_limit.wait_ms"
+	AttrRetryCount      = "hubspot.retry_count"
+)
+
+// OTelInstrumentation creates a span for every call with a child span for every attempt
+type OTelInstrumentation struct {
+	tracer OTelTracer
+}
+
+// otelSpanKey is the context key of the span started by OTelInstrumentation
+type otelSpanKey struct{}
+
+// NewOTelInstrumentation creates an Instrumentation reporting spans to tracer
+func NewOTelInstrumentation(tracer OTelTracer) *OTelInstrumentation {
+	return &OTelInstrumentation{
+		tracer: tracer,
+	}
+}
+
+// StartCall implements Instrumentation
+func (o *OTelInstrumentation) StartCall(req *Request) context.Context {
+	return o.start(req, nil)
+}
+
+// EndCall implements Instrumentation
+func (o *OTelInstrumentation) EndCall(ctx context.Context, event CallEvent) {
+	o.end(ctx, event.RequestEvent, map[string]any{
+		AttrRetryCount:      event.RetryCount,
+		AttrRateLimitWaitMs: event.RateLimitWait.Milliseconds(),
+	})
+}
+
+// StartAttempt implements Instrumentation
+func (o *OTelInstrumentation) StartAttempt(req *Request) context.Context {
+	attributes := map[string]any{}
+	if req.RetryCount > 0 {
+		attributes[AttrHTTPResendCount] = req.RetryCount
+	}
+	return o.start(req, attributes)
+}
+
+// EndAttempt implements Instrumentation
+func (o *OTelInstrumentation) EndAttempt(ctx context.Context, event AttemptEvent) {
+	o.end(ctx, event.RequestEvent, nil)
+}
+
+// start starts a span named after the method and path template
+func (o *OTelInstrumentation) start(req *Request, attributes map[string]any) context.Context {
+	template := PathTemplate(req.Path)
+	if attributes == nil {
+		attributes = make(map[string]any)
+	}
+	attributes[AttrHTTPMethod] = req.Method
+	attributes[AttrURLTemplate] = template
+	if req.ResourceType != "" {
+		attributes[AttrResourceType] = req.ResourceType
+	}
+
+	ctx, span := o.tracer.Start(req.Context, req.Method+" "+template, attributes)
+	return context.WithValue(ctx, otelSpanKey{}, span)
+}
+
+// end sets the outcome attributes and ends the span started for ctx
+func (o *OTelInstrumentation) end(ctx context.Context, event RequestEvent, attributes map[string]any) {
+	span, ok := ctx.Value(otelSpanKey{}).(OTelSpan)
+	if !ok {
+		return
+	}
+
+	if attributes == nil {
+		attributes = make(map[string]any)
+	}
+	if event.StatusCode != 0 {
+		attributes[AttrHTTPStatusCode] = event.StatusCode
+	}
+	if event.RequestBytes > 0 {
+		attributes[AttrHTTPRequestSize] = event.RequestBytes
+	}
+	if event.ResponseBytes > 0 {
+		attributes[AttrHTTPResponseSize] = event.ResponseBytes
+	}
+	if event.CorrelationID != "" {
+		attributes[AttrCorrelationID] = event.CorrelationID
+	}
+	if event.Err != nil {
+		if event.StatusCode != 0 {
+			attributes[AttrErrorType] = fmt.Sprint(event.StatusCode)
+		} else {
+			attributes[AttrErrorType] = fmt.Sprintf("%T", event.Err)
+		}
+	}
+
+	span.SetAttributes(attributes)
+	if event.Err != nil {
+		span.RecordError(event.Err)
+	}
+	span.End()
+}
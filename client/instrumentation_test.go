@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingInstrumentation records every event it receives
+type recordingInstrumentation struct {
+	mu       sync.Mutex
+	calls    []CallEvent
+	attempts []AttemptEvent
+}
+
+func (r *recordingInstrumentation) StartCall(req *Request) context.Context { return req.Context }
+
+func (r *recordingInstrumentation) EndCall(ctx context.Context, event CallEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, event)
+}
+
+func (r *recordingInstrumentation) StartAttempt(req *Request) context.Context { return req.Context }
+
+func (r *recordingInstrumentation) EndAttempt(ctx context.Context, event AttemptEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, event)
+}
+
+// newFailingOnceServer returns a server that fails the first request with a 503
+func newFailingOnceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	attempts := 0
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-HubSpot-Correlation-Id", "corr-"+string(rune('0'+attempts)))
+		w.Header().Set("X-HubSpot-RateLimit-Max", "100")
+		w.Header().Set("X-HubSpot-RateLimit-Remaining", "90")
+		w.Header().Set("X-HubSpot-RateLimit-Daily", "1000")
+		w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "500")
+		if attempts == 1 {
+			respondJSON(w, http.StatusServiceUnavailable, `{"message": "unavailable"}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"id": "123"}`)
+	})
+	return server
+}
+
+// TestInstrumentation tests the events reported for a call with a retry
+func TestInstrumentation(t *testing.T) {
+	server := newFailingOnceServer(t)
+	defer server.Close()
+
+	recorder := &recordingInstrumentation{}
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+		WithInstrumentation(recorder),
+	)
+	require.NoError(t, err)
+
+	req := NewRequest("PATCH", "/crm/v3/objects/contacts/123").WithResourceType("contacts").WithBody(map[string]string{"a": "b"})
+	_, err = c.Do(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, recorder.attempts, 2)
+	first, second := recorder.attempts[0], recorder.attempts[1]
+	assert.Equal(t, http.StatusServiceUnavailable, first.StatusCode)
+	assert.Error(t, first.Err)
+	assert.Equal(t, 0, first.RetryCount)
+	assert.Equal(t, "corr-1", first.CorrelationID)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, 1, second.RetryCount)
+	assert.Equal(t, 500, second.RateLimit.DailyRemaining)
+
+	require.Len(t, recorder.calls, 1)
+	call := recorder.calls[0]
+	assert.Equal(t, "PATCH", call.Method)
+	assert.Equal(t, "/crm/v3/objects/contacts/{id}", call.PathTemplate)
+	assert.Equal(t, "contacts", call.ResourceType)
+	assert.Equal(t, http.StatusOK, call.StatusCode)
+	assert.Equal(t, 1, call.RetryCount)
+	assert.Equal(t, "corr-2", call.CorrelationID)
+	assert.Equal(t, len(`{"a":"b"}`), call.RequestBytes)
+	assert.Equal(t, len(`{"id": "123"}`), call.ResponseBytes)
+	assert.NoError(t, call.Err)
+	assert.GreaterOrEqual(t, call.Latency, second.Latency)
+}
+
+// fakeSpan records what OTelInstrumentation does with a span
+type fakeSpan struct {
+	name       string
+	parent     *fakeSpan
+	attributes map[string]any
+	err        error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttributes(attributes map[string]any) {
+	for k, v := range attributes {
+		s.attributes[k] = v
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+
+func (s *fakeSpan) End() { s.ended = true }
+
+type fakeSpanKey struct{}
+
+// fakeTracer records every span started
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attributes map[string]any) (context.Context, OTelSpan) {
+	parent, _ := ctx.Value(fakeSpanKey{}).(*fakeSpan)
+	span := &fakeSpan{name: name, parent: parent, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, fakeSpanKey{}, span), span
+}
+
+// TestOTelInstrumentation tests call and attempt spans
+func TestOTelInstrumentation(t *testing.T) {
+	server := newFailingOnceServer(t)
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+		WithInstrumentation(NewOTelInstrumentation(tracer)),
+	)
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/deals/42").WithResourceType("deals"))
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 3)
+	call, first, second := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+
+	assert.Equal(t, "GET /crm/v3/objects/deals/{id}", call.name)
+	assert.Nil(t, call.parent)
+	assert.Equal(t, call, first.parent)
+	assert.Equal(t, call, second.parent)
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended)
+		assert.Equal(t, "GET", span.attributes[AttrHTTPMethod])
+		assert.Equal(t, "deals", span.attributes[AttrResourceType])
+	}
+
+	assert.Equal(t, 503, first.attributes[AttrHTTPStatusCode])
+	assert.Equal(t, "503", first.attributes[AttrErrorType])
+	assert.Error(t, first.err)
+	assert.Equal(t, 1, second.attributes[AttrHTTPResendCount])
+	assert.NoError(t, second.err)
+	assert.Equal(t, 1, call.attributes[AttrRetryCount])
+	assert.Equal(t, "corr-2", call.attributes[AttrCorrelationID])
+}
+
+// TestPrometheusMetrics tests the text exposition output
+func TestPrometheusMetrics(t *testing.T) {
+	server := newFailingOnceServer(t)
+	defer server.Close()
+
+	metrics := NewPrometheusMetrics()
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+		WithInstrumentation(metrics),
+	)
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/contacts/1").WithResourceType("contacts"))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	labels := `method="GET",path="/crm/v3/objects/contacts/{id}",resource_type="contacts"`
+	for _, line := range []string{
+		"# TYPE hubspot_api_calls_total counter",
+		`hubspot_api_calls_total{` + labels + `,status="200"} 1`,
+		`hubspot_api_attempts_total{` + labels + `,status="503"} 1`,
+		`hubspot_api_attempts_total{` + labels + `,status="200"} 1`,
+		`hubspot_api_retries_total{` + labels + `} 1`,
+		"# TYPE hubspot_api_call_duration_seconds histogram",
+		`hubspot_api_call_duration_seconds_bucket{` + labels + `,le="+Inf"} 1`,
+		`hubspot_api_call_duration_seconds_count{` + labels + `} 1`,
+		"hubspot_api_daily_limit 1000",
+		"hubspot_api_daily_remaining 500",
+		"hubspot_api_window_remaining 90",
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+
+	// Every sample line is a name, optional labels and a value
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		assert.Len(t, strings.Fields(strings.Replace(line, "} ", "}\t", 1)), 2, line)
+	}
+
+	assert.Equal(t, `a\"b\\c\nd`, escapeLabelValue("a\"b\\c\nd"))
+}
+
+// TestInstrumentation_TokenPaths tests that OAuth tokens in paths never reach span names or metric labels
+func TestInstrumentation_TokenPaths(t *testing.T) {
+	const token = "CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf3xBKmlwHjX7OIpuIFEavB2"
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{"hub_id": 62515, "token_type": "access"}`)
+	})
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	metrics := NewPrometheusMetrics()
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryEnabled(false),
+		WithInstrumentation(NewOTelInstrumentation(tracer), metrics),
+	)
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), NewRequest("GET", "/oauth/v1/access-tokens/"+token).WithResourceType("oauth"))
+	require.NoError(t, err)
+
+	for _, span := range tracer.spans {
+		assert.NotContains(t, span.name, token)
+		assert.NotContains(t, fmt.Sprint(span.attributes), token)
+	}
+	assert.Equal(t, "GET /oauth/v1/access-tokens/{id}", tracer.spans[0].name)
+
+	var b strings.Builder
+	require.NoError(t, metrics.WriteText(&b))
+	assert.NotContains(t, b.String(), token)
+	assert.Contains(t, b.String(), `path="/oauth/v1/access-tokens/{id}"`)
+}
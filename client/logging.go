@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+)
+
+// LogConfig configures what the client logs about requests and responses
+type LogConfig struct {
+	Redaction
+
+	// MaxBodyBytes truncates logged bodies. Zero disables body logging.
+	MaxBodyBytes int
+
+	// BodySampleRate is the fraction of requests, between 0 and 1, whose bodies are logged
+	BodySampleRate float64
+
+	// ResourceLevels sets the minimum level logged for requests of a resource type, e.g. to silence
+	// debug logging for a noisy resource or to only see warnings for it
+	ResourceLevels map[string]slog.Level
+}
+
+// DefaultLogConfig uses DefaultRedaction and logs bodies up to 1KB
+func DefaultLogConfig() LogConfig {
+	return LogConfig{
+		Redaction:      DefaultRedaction(),
+		MaxBodyBytes:   1024,
+		BodySampleRate: 1,
+	}
+}
+
+// requestLogger logs requests and responses with redaction
+type requestLogger struct {
+	*redactor
+	logger *slog.Logger
+	config LogConfig
+}
+
+// newRequestLogger creates a requestLogger
+func newRequestLogger(logger *slog.Logger, cfg LogConfig) *requestLogger {
+	return &requestLogger{
+		redactor: newRedactor(cfg.Redaction),
+		logger:   logger,
+		config:   cfg,
+	}
+}
+
+// enabled reports whether a record at level is logged for the request's resource type
+func (l *requestLogger) enabled(ctx context.Context, req *Request, level slog.Level) bool {
+	if req != nil {
+		if minLevel, ok := l.config.ResourceLevels[req.ResourceType]; ok && level < minLevel {
+			return false
+		}
+	}
+	return l.logger.Enabled(ctx, level)
+}
+
+// log logs a record for a request, tagged with its method, path and resource type
+func (l *requestLogger) log(req *Request, level slog.Level, msg string, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if req != nil && req.Context != nil {
+		ctx = req.Context
+	}
+	if !l.enabled(ctx, req, level) {
+		return
+	}
+
+	if req != nil {
+		attrs = append([]slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", l.redactPath(req.Path)),
+			slog.String("resourceType", req.ResourceType),
+		}, attrs...)
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logRequest logs an outgoing request at debug level
+func (l *requestLogger) logRequest(req *Request, httpReq *http.Request, body []byte) {
+	if !l.enabled(req.Context, req, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("query", l.redactQuery(httpReq.URL.Query())),
+		slog.Any("headers", l.redactHeaders(httpReq.Header)),
+		slog.Int("attempt", req.RetryCount),
+	}
+	if body := l.body(body); body != "" {
+		attrs = append(attrs, slog.String("body", body))
+	}
+	l.log(req, slog.LevelDebug, "HubSpot API request", attrs...)
+}
+
+// logResponse logs a response at debug level, or as a warning or error for error responses
+func (l *requestLogger) logResponse(req *Request, resp *Response) {
+	level := slog.LevelDebug
+	switch {
+	case resp.StatusCode >= 500:
+		level = slog.LevelError
+	case resp.StatusCode >= 400:
+		level = slog.LevelWarn
+	}
+	if !l.enabled(req.Context, req, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Int("status", resp.StatusCode),
+		slog.Any("headers", l.redactHeaders(resp.Headers)),
+	}
+	if resp.HubSpotError != nil {
+		attrs = append(attrs,
+			slog.String("error", l.redactString(resp.HubSpotError.Message)),
+			slog.String("category", resp.HubSpotError.Category),
+			slog.String("correlationId", resp.HubSpotError.CorrelationID),
+		)
+	}
+	if body := l.body(resp.Body); body != "" {
+		attrs = append(attrs, slog.String("body", body))
+	}
+	l.log(req, level, "HubSpot API response", attrs...)
+}
+
+// body returns the redacted and truncated body to log, or "" if the body is not logged
+func (l *requestLogger) body(body []byte) string {
+	if len(body) == 0 || l.config.MaxBodyBytes <= 0 {
+		return ""
+	}
+	if l.config.BodySampleRate < 1 && rand.Float64() >= l.config.BodySampleRate {
+		return ""
+	}
+
+	redacted, err := l.redactBody(body)
+	if err != nil {
+		// Bodies that can't be redacted field by field are never logged
+		return fmt.Sprintf("[%d bytes, not JSON]", len(body))
+	}
+
+	if len(redacted) > l.config.MaxBodyBytes {
+		return fmt.Sprintf("%s...[truncated, %d bytes]", redacted[:l.config.MaxBodyBytes], len(redacted))
+	}
+	return string(redacted)
+}
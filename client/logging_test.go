@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoggedClient returns a client logging at debug level into the returned buffer
+func newLoggedClient(t *testing.T, serverURL string, opts ...Option) (*Client, *bytes.Buffer) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewClient(append([]Option{
+		WithBaseURL(serverURL),
+		WithAccessToken("secret-token"),
+		WithRetryEnabled(false),
+		WithLogger(logger),
+	}, opts...)...)
+	require.NoError(t, err)
+	return c, &buf
+}
+
+// TestLogging_Redaction tests that credentials and PII never reach the log
+func TestLogging_Redaction(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		respondJSON(w, http.StatusOK, `{"id": "1", "properties": {"email": "jane@example.com", "mobilephone": "555-0100", "firstname": "Jane"}}`)
+	})
+	defer server.Close()
+
+	c, buf := newLoggedClient(t, server.URL)
+
+	req := NewRequest("POST", "/crm/v3/objects/contacts/search").WithResourceType("contacts")
+	req.AddQueryParam("hapikey", "legacy-token")
+	req.WithBody(map[string]any{
+		"filterGroups": []any{map[string]any{
+			"filters": []any{map[string]any{"propertyName": "phone", "operator": "EQ", "value": "555-0100"}},
+		}},
+		"query": "jane@example.com",
+	})
+	_, err := c.Do(context.Background(), req)
+	require.NoError(t, err)
+
+	logs := buf.String()
+	for _, secret := range []string{"secret-token", "jane@example.com", "555-0100", "session=abc"} {
+		assert.NotContains(t, logs, secret)
+	}
+	assert.Contains(t, logs, redactedValue)
+	assert.Contains(t, logs, "Jane")
+	assert.Contains(t, logs, "HubSpot API request")
+	assert.Contains(t, logs, "HubSpot API response")
+}
+
+// TestLogging_ZeroRedaction tests that credential headers are redacted when a LogConfig leaves Redaction empty
+func TestLogging_ZeroRedaction(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		respondJSON(w, http.StatusOK, `{"id": "1"}`)
+	})
+	defer server.Close()
+
+	c, buf := newLoggedClient(t, server.URL, WithLogConfig(LogConfig{MaxBodyBytes: 1024, BodySampleRate: 1}))
+	_, err := c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/contacts/1"))
+	require.NoError(t, err)
+
+	logs := buf.String()
+	assert.NotContains(t, logs, "secret-token")
+	assert.NotContains(t, logs, "session=abc")
+	assert.Contains(t, logs, redactedValue)
+}
+
+// TestLogging_EmailInPath tests that email addresses used as IDs are redacted from paths
+func TestLogging_EmailInPath(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusNotFound, `{"message": "Contact jane@example.com not found", "category": "OBJECT_NOT_FOUND"}`)
+	})
+	defer server.Close()
+
+	c, buf := newLoggedClient(t, server.URL)
+	req := NewRequest("GET", "/crm/v3/objects/contacts/jane%40example.com")
+	req.AddQueryParam("idProperty", "email")
+	_, err := c.Do(context.Background(), req)
+	require.Error(t, err)
+
+	logs := buf.String()
+	assert.NotContains(t, logs, "jane@example.com")
+	assert.NotContains(t, logs, "jane%40example.com")
+	assert.Contains(t, logs, `"level":"WARN"`)
+	assert.Contains(t, logs, "OBJECT_NOT_FOUND")
+}
+
+// TestLogging_Bodies tests body truncation and disabling
+func TestLogging_Bodies(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{"results": ["`+strings.Repeat("a", 200)+`"]}`)
+	})
+	defer server.Close()
+
+	t.Run("Truncated", func(t *testing.T) {
+		cfg := DefaultLogConfig()
+		cfg.MaxBodyBytes = 20
+		c, buf := newLoggedClient(t, server.URL, WithLogConfig(cfg))
+		_, err := c.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "truncated")
+		assert.NotContains(t, buf.String(), strings.Repeat("a", 21))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := DefaultLogConfig()
+		cfg.MaxBodyBytes = 0
+		c, buf := newLoggedClient(t, server.URL, WithLogConfig(cfg))
+		_, err := c.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+
+		assert.NotContains(t, buf.String(), `"body"`)
+	})
+
+	t.Run("Not sampled", func(t *testing.T) {
+		cfg := DefaultLogConfig()
+		cfg.BodySampleRate = 0
+		c, buf := newLoggedClient(t, server.URL, WithLogConfig(cfg))
+		_, err := c.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+
+		assert.NotContains(t, buf.String(), `"body"`)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewClient(WithLogConfig(LogConfig{BodySampleRate: 2}))
+		require.Error(t, err)
+	})
+}
+
+// TestLogging_ResourceLevels tests per-resource minimum levels
+func TestLogging_ResourceLevels(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{}`)
+	})
+	defer server.Close()
+
+	c, buf := newLoggedClient(t, server.URL, WithResourceLogLevel("contacts", slog.LevelWarn))
+
+	_, err := c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/contacts").WithResourceType("contacts"))
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	_, err = c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/deals").WithResourceType("deals"))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"resourceType":"deals"`)
+}
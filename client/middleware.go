@@ -0,0 +1,61 @@
+package client
+
+import "fmt"
+
+// Middleware wraps a Handler with additional behavior
+type Middleware func(next Handler) Handler
+
+// MiddlewareStage determines where a user Middleware is inserted relative to the built-in stages
+//
+// The built-in chain is, from outermost to innermost:
+// call instrumentation → cache → auth → rate limit → retry → attempt instrumentation → circuit breaker → HTTP
+type MiddlewareStage int
+
+const (
+	// StageOutermost runs outside the cache and auth stages, once per call to Do
+	StageOutermost MiddlewareStage = iota
+	// StageAfterAuth runs after the Authorization header is set and before rate limiting, once per call to Do not served from the cache
+	StageAfterAuth
+	// StageAfterRateLimit runs after a rate limit token was acquired and before the retry loop, once per call to Do not served from the cache
+	StageAfterRateLimit
+	// StagePerAttempt runs inside the retry loop and outside the circuit breaker, once for every attempt
+	StagePerAttempt
+)
+
+// String returns the name of the stage
+func (s MiddlewareStage) String() string {
+	switch s {
+	case StageOutermost:
+		return "outermost"
+	case StageAfterAuth:
+		return "after-auth"
+	case StageAfterRateLimit:
+		return "after-rate-limit"
+	case StagePerAttempt:
+		return "per-attempt"
+	default:
+		return fmt.Sprintf("MiddlewareStage(%d)", int(s))
+	}
+}
+
+func (s MiddlewareStage) valid() bool {
+	return s >= StageOutermost && s <= StagePerAttempt
+}
+
+// stagedMiddleware is a Middleware registered at a given stage
+type stagedMiddleware struct {
+	stage      MiddlewareStage
+	middleware Middleware
+}
+
+// wrapMiddleware wraps a handler with every user middleware registered for the given stage.
+// Middleware registered first ends up outermost.
+func (c *Client) wrapMiddleware(stage MiddlewareStage, next Handler) Handler {
+	handler := next
+	for i := len(c.config.middleware) - 1; i >= 0; i-- {
+		if c.config.middleware[i].stage == stage {
+			handler = c.config.middleware[i].middleware(handler)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMiddleware appends its name to calls every time it is invoked
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			*calls = append(*calls, name)
+			return next(req)
+		}
+	}
+}
+
+// TestWithMiddleware tests user middleware ordering and placement
+func TestWithMiddleware(t *testing.T) {
+	t.Run("Ordering within outermost stage", func(t *testing.T) {
+		var calls []string
+		server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		client, err := NewClient(
+			WithBaseURL(server.URL),
+			WithRateLimitEnabled(false),
+			WithRetryEnabled(false),
+			WithMiddleware(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls)),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("Header injection after auth", func(t *testing.T) {
+		server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer test-token", r.Header.Get("X-Seen-Auth"))
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		client, err := NewClient(
+			WithBaseURL(server.URL),
+			WithAccessToken("test-token"),
+			WithRateLimitEnabled(false),
+			WithRetryEnabled(false),
+			WithStageMiddleware(StageAfterAuth, func(next Handler) Handler {
+				return func(req *Request) (*Response, error) {
+					req.AddHeader("X-Seen-Auth", req.Headers["Authorization"])
+					return next(req)
+				}
+			}),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Per attempt middleware runs on every retry", func(t *testing.T) {
+		var outer, perAttempt []string
+		attempts := 0
+		server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				respondJSON(w, http.StatusInternalServerError, `{"message": "Server error"}`)
+				return
+			}
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		client, err := NewClient(
+			WithBaseURL(server.URL),
+			WithRateLimitEnabled(false),
+			WithRetryMaxAttempts(3),
+			WithRetryBackoff(time.Millisecond, 10*time.Millisecond),
+			WithMiddleware(recordingMiddleware("outer", &outer)),
+			WithStageMiddleware(StagePerAttempt, recordingMiddleware("attempt", &perAttempt)),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+		assert.Len(t, outer, 1)
+		assert.Len(t, perAttempt, 3)
+	})
+
+	t.Run("Response inspection", func(t *testing.T) {
+		var status int
+		server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusAccepted, `{}`)
+		})
+		defer server.Close()
+
+		client, err := NewClient(
+			WithBaseURL(server.URL),
+			WithRateLimitEnabled(false),
+			WithRetryEnabled(false),
+			WithMiddleware(func(next Handler) Handler {
+				return func(req *Request) (*Response, error) {
+					resp, err := next(req)
+					if resp != nil {
+						status = resp.StatusCode
+					}
+					return resp, err
+				}
+			}),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, status)
+	})
+
+	t.Run("Invalid stage", func(t *testing.T) {
+		_, err := NewClient(WithStageMiddleware(MiddlewareStage(42), func(next Handler) Handler { return next }))
+		require.Error(t, err)
+	})
+
+	t.Run("Nil middleware", func(t *testing.T) {
+		_, err := NewClient(WithMiddleware(nil))
+		require.Error(t, err)
+	})
+}
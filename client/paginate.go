@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Page is one page of results of a paginated endpoint
+type Page[T any] struct {
+	Results []T
+
+	// Next is the cursor of the next page, "" on the last page
+	Next string
+}
+
+// PageFunc fetches the page at cursor, which is "" for the first page. HubSpot endpoints page with different
+// cursors, e.g. an "after" token, an offset or a start ID; a PageFunc hides which one behind an opaque string.
+type PageFunc[T any] func(ctx context.Context, cursor string) (Page[T], error)
+
+// Paginate returns an iterator over the results of every page returned by fetch, fetching each page when the
+// previous one has been consumed. Iteration stops after the last page, when the loop breaks or when ctx is done;
+// Limit caps the number of results. A failed fetch yields its error and ends iteration.
+//
+//	for obj, err := range client.Paginate(ctx, fetch) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Paginate[T any](ctx context.Context, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := ""
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, result := range page.Results {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(result, nil) {
+					return
+				}
+			}
+
+			if page.Next == "" {
+				return
+			}
+			// An endpoint returning the cursor it was called with would otherwise be paged forever
+			if page.Next == cursor {
+				yield(zero, fmt.Errorf("pagination cursor %q did not advance", cursor))
+				return
+			}
+			cursor = page.Next
+		}
+	}
+}
+
+// Limit stops seq after it has yielded maxItems results, so an iterator from Paginate fetches no further pages.
+// Errors are yielded but not counted.
+//
+//	for obj, err := range client.Limit(objectsClient.All(ctx, "contacts"), 100) {
+func Limit[T any](seq iter.Seq2[T, error], maxItems int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if maxItems <= 0 {
+			return
+		}
+
+		yielded := 0
+		for result, err := range seq {
+			if !yield(result, err) {
+				return
+			}
+			if err == nil {
+				yielded++
+				if yielded >= maxItems {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaginate tests iterating over paginated endpoints
+func TestPaginate(t *testing.T) {
+	// pages serves three pages of two results each, counting the fetches
+	pages := func(fetches *int) PageFunc[int] {
+		return func(ctx context.Context, cursor string) (Page[int], error) {
+			*fetches++
+			start := 0
+			if cursor != "" {
+				start, _ = strconv.Atoi(cursor)
+			}
+			page := Page[int]{Results: []int{start, start + 1}}
+			if start+2 < 6 {
+				page.Next = strconv.Itoa(start + 2)
+			}
+			return page, nil
+		}
+	}
+
+	t.Run("Yields every page", func(t *testing.T) {
+		fetches := 0
+		var results []int
+		for result, err := range Paginate(context.Background(), pages(&fetches)) {
+			require.NoError(t, err)
+			results = append(results, result)
+		}
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, results)
+		assert.Equal(t, 3, fetches)
+	})
+
+	t.Run("Break stops fetching", func(t *testing.T) {
+		fetches := 0
+		for result, err := range Paginate(context.Background(), pages(&fetches)) {
+			require.NoError(t, err)
+			if result == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, fetches)
+	})
+
+	t.Run("Max items", func(t *testing.T) {
+		fetches := 0
+		var results []int
+		for result, err := range Limit(Paginate(context.Background(), pages(&fetches)), 3) {
+			require.NoError(t, err)
+			results = append(results, result)
+		}
+		assert.Equal(t, []int{0, 1, 2}, results)
+		assert.Equal(t, 2, fetches)
+
+		for range Limit(Paginate(context.Background(), pages(&fetches)), 0) {
+			t.Error("unexpected result")
+		}
+	})
+
+	t.Run("Context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fetches := 0
+		var results []int
+		var iterErr error
+		for result, err := range Paginate(ctx, pages(&fetches)) {
+			if err != nil {
+				iterErr = err
+				continue
+			}
+			results = append(results, result)
+			cancel()
+		}
+		assert.ErrorIs(t, iterErr, context.Canceled)
+		assert.Equal(t, []int{0}, results)
+		assert.Equal(t, 1, fetches)
+	})
+
+	t.Run("Fetch error ends iteration", func(t *testing.T) {
+		failure := errors.New("fetch failed")
+		var errs []error
+		for _, err := range Paginate(context.Background(), func(ctx context.Context, cursor string) (Page[int], error) {
+			return Page[int]{}, failure
+		}) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], failure)
+	})
+
+	t.Run("Cursor that doesn't advance", func(t *testing.T) {
+		var errs []error
+		for _, err := range Paginate(context.Background(), func(ctx context.Context, cursor string) (Page[int], error) {
+			return Page[int]{Results: []int{1}, Next: "same"}, nil
+		}) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "did not advance")
+	})
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultPoolIdleTimeout is how long a portal client stays cached without being used
+const DefaultPoolIdleTimeout = 30 * time.Minute
+
+// TokenSourceFunc returns the TokenSource for a portal, e.g. by loading its stored OAuth tokens
+type TokenSourceFunc func(ctx context.Context, portalID int) (TokenSource, error)
+
+// Pool lazily creates and caches one Client per portal
+//
+// Every portal client has its own token source, burst rate limiter, daily quota and cache namespace. Portal
+// clients sharing a rate limit store use their own keys, since HubSpot limits every portal separately.
+// Clients idle for longer than the idle timeout are evicted, so callers should get a
+// client from the pool for each unit of work rather than holding on to it.
+type Pool struct {
+	tokenSources TokenSourceFunc
+	idleTimeout  time.Duration
+	opts         []Option
+
+	mu      sync.Mutex
+	clients map[int]*pooledClient
+}
+
+// pooledClient is a cached portal client
+type pooledClient struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewPool creates a new Pool. The options are applied to every portal client.
+// An idleTimeout of zero uses DefaultPoolIdleTimeout.
+func NewPool(tokenSources TokenSourceFunc, idleTimeout time.Duration, opts ...Option) (*Pool, error) {
+	if tokenSources == nil {
+		return nil, fmt.Errorf("token source func cannot be nil")
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultPoolIdleTimeout
+	}
+
+	// Validate the options once up front instead of on every portal
+	cfg := NewConfig()
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Pool{
+		tokenSources: tokenSources,
+		idleTimeout:  idleTimeout,
+		opts:         opts,
+		clients:      make(map[int]*pooledClient),
+	}, nil
+}
+
+// Get returns the client for a portal, creating it if needed
+func (p *Pool) Get(ctx context.Context, portalID int) (*Client, error) {
+	now := time.Now()
+
+	p.mu.Lock()
+	p.evictIdleLocked(now)
+	if pooled, ok := p.clients[portalID]; ok {
+		pooled.lastUsed = now
+		p.mu.Unlock()
+		return pooled.client, nil
+	}
+	p.mu.Unlock()
+
+	// Create outside the lock so a slow token source doesn't block other portals
+	tokenSource, err := p.tokenSources(ctx, portalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token source for portal %d: %w", portalID, err)
+	}
+	opts := append(append([]Option(nil), p.opts...), WithTokenSource(tokenSource), withCacheNamespace(portalID), withRateLimitNamespace(portalID))
+	c, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another caller may have created the client concurrently; keep the first one so the portal has a single rate limiter
+	if pooled, ok := p.clients[portalID]; ok {
+		pooled.lastUsed = now
+		return pooled.client, nil
+	}
+	p.clients[portalID] = &pooledClient{client: c, lastUsed: now}
+
+	return c, nil
+}
+
+// Remove evicts the client for a portal, e.g. after the app is uninstalled
+func (p *Pool) Remove(portalID int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, portalID)
+}
+
+// EvictIdle evicts every client that has been idle for longer than the idle timeout.
+// Idle clients are also evicted on every call to Get.
+func (p *Pool) EvictIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictIdleLocked(time.Now())
+}
+
+// Len returns the number of cached clients
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// evictIdleLocked removes idle clients. p.mu must be held.
+func (p *Pool) evictIdleLocked(now time.Time) {
+	for portalID, pooled := range p.clients {
+		if now.Sub(pooled.lastUsed) > p.idleTimeout {
+			delete(p.clients, portalID)
+		}
+	}
+}
+
+// PoolClient returns a service client for a portal, e.g.
+//
+//	contactsClient, err := client.PoolClient(ctx, pool, portalID, contacts.NewClient)
+func PoolClient[T any](ctx context.Context, pool *Pool, portalID int, newClient func(*Client) T) (T, error) {
+	c, err := pool.Get(ctx, portalID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return newClient(c), nil
+}
+
+// withCacheNamespace appends a portal to the cache namespace, so portal clients sharing a cache store keep
+// their responses apart
+func withCacheNamespace(portalID int) Option {
+	return func(cfg *Config) error {
+		if cfg.Cache == nil {
+			return nil
+		}
+		cache := *cfg.Cache
+		if cache.Namespace != "" {
+			cache.Namespace += "/"
+		}
+		cache.Namespace += "portal-" + strconv.Itoa(portalID)
+		cfg.Cache = &cache
+		return nil
+	}
+}
+
+// withRateLimitNamespace appends a portal to the rate limit store key, so portal clients sharing a store draw from
+// their own budgets
+func withRateLimitNamespace(portalID int) Option {
+	return func(cfg *Config) error {
+		if cfg.RateLimit.Store == nil {
+			return nil
+		}
+		cfg.RateLimit.StoreKey += ":portal-" + strconv.Itoa(portalID)
+		return nil
+	}
+}
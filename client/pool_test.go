@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// portalTokenSources returns a TokenSourceFunc issuing "token-<portalID>" and counting calls
+func portalTokenSources(calls *atomic.Int32) TokenSourceFunc {
+	return func(ctx context.Context, portalID int) (TokenSource, error) {
+		calls.Add(1)
+		if portalID < 0 {
+			return nil, errors.New("portal not installed")
+		}
+		return StaticTokenSource(fmt.Sprintf("token-%d", portalID)), nil
+	}
+}
+
+// keyRecordingStore records the keys of a RateLimitStore
+type keyRecordingStore struct {
+	RateLimitStore
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (s *keyRecordingStore) Update(ctx context.Context, key string, fn func(state BucketState) BucketState) error {
+	s.mu.Lock()
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[key] = true
+	s.mu.Unlock()
+	return s.RateLimitStore.Update(ctx, key, fn)
+}
+
+func (s *keyRecordingStore) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Collect(maps.Keys(s.seen))
+}
+
+// TestPool tests lazy creation, caching and eviction of portal clients
+func TestPool(t *testing.T) {
+	t.Run("Clients are cached per portal", func(t *testing.T) {
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), time.Minute)
+		require.NoError(t, err)
+
+		first, err := pool.Get(context.Background(), 1)
+		require.NoError(t, err)
+		again, err := pool.Get(context.Background(), 1)
+		require.NoError(t, err)
+		other, err := pool.Get(context.Background(), 2)
+		require.NoError(t, err)
+
+		assert.Same(t, first, again)
+		assert.NotSame(t, first, other)
+		assert.NotSame(t, first.rateLimiter, other.rateLimiter)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, 2, pool.Len())
+	})
+
+	t.Run("Portal clients use their own token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token-42", r.Header.Get("Authorization"))
+			respondJSON(w, http.StatusOK, `{}`)
+		}))
+		defer server.Close()
+
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), 0, WithBaseURL(server.URL), WithRetryEnabled(false))
+		require.NoError(t, err)
+
+		c, err := pool.Get(context.Background(), 42)
+		require.NoError(t, err)
+		_, err = c.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Portal clients share a cache store by namespace", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, `{"token": "`+r.Header.Get("Authorization")+`"}`)
+		}))
+		defer server.Close()
+
+		var calls atomic.Int32
+		store := NewLRUCacheStore(10)
+		pool, err := NewPool(portalTokenSources(&calls), 0, WithBaseURL(server.URL), WithRetryEnabled(false),
+			WithCache(CacheConfig{TTLs: map[string]time.Duration{"schemas": time.Minute}, Store: store}))
+		require.NoError(t, err)
+
+		for _, portalID := range []int{1, 2} {
+			c, err := pool.Get(context.Background(), portalID)
+			require.NoError(t, err)
+			resp, err := c.Do(context.Background(), NewRequest("GET", "/crm/v3/schemas").WithResourceType("schemas"))
+			require.NoError(t, err)
+			assert.False(t, resp.Cached)
+			assert.Contains(t, string(resp.Body), fmt.Sprintf("token-%d", portalID))
+		}
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("Portal clients share a rate limit store by key", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, `{}`)
+		}))
+		defer server.Close()
+
+		store := &keyRecordingStore{RateLimitStore: NewMemoryRateLimitStore()}
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), 0, WithBaseURL(server.URL), WithRetryEnabled(false),
+			WithRateLimitStore(store, "app"), WithRateLimitPolicy(SearchClass, RateLimitPolicy{MaxBurst: 5, Interval: time.Second}))
+		require.NoError(t, err)
+
+		for _, portalID := range []int{1, 2} {
+			c, err := pool.Get(context.Background(), portalID)
+			require.NoError(t, err)
+			_, err = c.Do(context.Background(), NewRequest("POST", "/crm/v3/objects/contacts/search").WithBody(`{}`))
+			require.NoError(t, err)
+		}
+		assert.ElementsMatch(t, []string{"app:portal-1", "app:portal-1:" + SearchClass, "app:portal-2", "app:portal-2:" + SearchClass}, store.keys())
+	})
+
+	t.Run("Idle clients are evicted", func(t *testing.T) {
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), 20*time.Millisecond)
+		require.NoError(t, err)
+
+		_, err = pool.Get(context.Background(), 1)
+		require.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		pool.EvictIdle()
+
+		assert.Equal(t, 0, pool.Len())
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), time.Minute)
+		require.NoError(t, err)
+
+		_, err = pool.Get(context.Background(), 1)
+		require.NoError(t, err)
+		pool.Remove(1)
+		assert.Equal(t, 0, pool.Len())
+	})
+
+	t.Run("Concurrent gets share one client", func(t *testing.T) {
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), time.Minute)
+		require.NoError(t, err)
+
+		clients := make([]*Client, 20)
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c, err := pool.Get(context.Background(), 7)
+				assert.NoError(t, err)
+				clients[i] = c
+			}()
+		}
+		wg.Wait()
+
+		for _, c := range clients {
+			assert.Same(t, clients[0], c)
+		}
+	})
+
+	t.Run("Token source error", func(t *testing.T) {
+		var calls atomic.Int32
+		pool, err := NewPool(portalTokenSources(&calls), time.Minute)
+		require.NoError(t, err)
+
+		_, err = pool.Get(context.Background(), -1)
+		require.Error(t, err)
+		assert.Equal(t, 0, pool.Len())
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		var calls atomic.Int32
+		_, err := NewPool(portalTokenSources(&calls), time.Minute, WithMiddleware(nil))
+		require.Error(t, err)
+	})
+}
+
+// TestPoolClient tests obtaining service clients from a pool
+func TestPoolClient(t *testing.T) {
+	var calls atomic.Int32
+	pool, err := NewPool(portalTokenSources(&calls), time.Minute)
+	require.NoError(t, err)
+
+	type serviceClient struct{ apiClient *Client }
+	svc, err := PoolClient(context.Background(), pool, 1, func(c *Client) *serviceClient {
+		return &serviceClient{apiClient: c}
+	})
+	require.NoError(t, err)
+
+	c, err := pool.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Same(t, c, svc.apiClient)
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Priority is the scheduling class of a request, set per call with WithPriority
+type Priority string
+
+const (
+	// PriorityHigh is for interactive, user-facing requests
+	PriorityHigh Priority = "high"
+	// PriorityNormal is the priority of requests that don't set one
+	PriorityNormal Priority = "normal"
+	// PriorityLow is for bulk jobs that can wait
+	PriorityLow Priority = "low"
+)
+
+// PriorityConfig configures priority scheduling in the rate limit stage
+type PriorityConfig struct {
+	// Weights are the relative shares of rate limit tokens given to each class while several classes are waiting,
+	// defaults to DefaultPriorityWeights. Classes without a weight get a weight of 1.
+	Weights map[Priority]int
+
+	// MaxInFlight bounds the number of requests sent concurrently, including their retries. Zero means no limit.
+	MaxInFlight int
+
+	// HighPriorityReserve is the fraction of the daily limit only PriorityHigh requests may use.
+	// Other requests fail once the remaining daily quota falls to the reserve.
+	HighPriorityReserve float64
+}
+
+// DefaultPriorityWeights gives high priority requests twice the share of normal requests and eight times that of low requests
+func DefaultPriorityWeights() map[Priority]int {
+	return map[Priority]int{
+		PriorityHigh:   8,
+		PriorityNormal: 4,
+		PriorityLow:    1,
+	}
+}
+
+// scheduler grants requests their turn to take a rate limit token in weighted fair order
+//
+// Only one granted request waits on the policy and general rate limiters at a time, so the order in which
+// waiting requests are granted is the order in which they get tokens. Classes are picked by stride scheduling: each grant
+// advances its class's pass by 1/weight, and the class with the lowest pass goes next.
+type scheduler struct {
+	weights     map[Priority]int
+	maxInFlight int
+
+	mu          sync.Mutex
+	queues      map[Priority][]*schedulerWaiter
+	pass        map[Priority]float64
+	virtualTime float64
+	inFlight    int
+	turnHeld    bool
+}
+
+// schedulerWaiter is a request waiting for its turn
+type schedulerWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// newScheduler creates a scheduler for cfg
+func newScheduler(cfg PriorityConfig) *scheduler {
+	weights := cfg.Weights
+	if len(weights) == 0 {
+		weights = DefaultPriorityWeights()
+	}
+
+	return &scheduler{
+		weights:     weights,
+		maxInFlight: cfg.MaxInFlight,
+		queues:      make(map[Priority][]*schedulerWaiter),
+		pass:        make(map[Priority]float64),
+	}
+}
+
+// acquire blocks until the request may take a rate limit token. The caller must call tokenTaken once it
+// has the token, or failed to get it, and done once the request completed.
+func (s *scheduler) acquire(ctx context.Context, priority Priority) error {
+	w := &schedulerWaiter{ready: make(chan struct{})}
+
+	s.mu.Lock()
+	if len(s.queues[priority]) == 0 {
+		// A class that was idle doesn't get credit for the time it didn't use
+		s.pass[priority] = max(s.pass[priority], s.virtualTime)
+	}
+	s.queues[priority] = append(s.queues[priority], w)
+	s.dispatchLocked()
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w.granted {
+		s.turnHeld = false
+		s.inFlight--
+	} else {
+		s.queues[priority] = slices.DeleteFunc(s.queues[priority], func(queued *schedulerWaiter) bool {
+			return queued == w
+		})
+	}
+	s.dispatchLocked()
+	return ctx.Err()
+}
+
+// tokenTaken passes the turn to the next waiting request
+func (s *scheduler) tokenTaken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.turnHeld = false
+	s.dispatchLocked()
+}
+
+// done releases the request's in-flight slot
+func (s *scheduler) done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight--
+	s.dispatchLocked()
+}
+
+// dispatchLocked grants the next request its turn if the turn and an in-flight slot are free. s.mu must be held.
+func (s *scheduler) dispatchLocked() {
+	if s.turnHeld || (s.maxInFlight > 0 && s.inFlight >= s.maxInFlight) {
+		return
+	}
+
+	priority, ok := s.nextLocked()
+	if !ok {
+		return
+	}
+
+	w := s.queues[priority][0]
+	s.queues[priority] = s.queues[priority][1:]
+	s.virtualTime = s.pass[priority]
+	s.pass[priority] += 1 / float64(s.weight(priority))
+
+	w.granted = true
+	s.turnHeld = true
+	s.inFlight++
+	close(w.ready)
+}
+
+// nextLocked returns the waiting class with the lowest pass, preferring heavier classes on ties. s.mu must be held.
+func (s *scheduler) nextLocked() (Priority, bool) {
+	var next Priority
+	found := false
+	for priority, queue := range s.queues {
+		if len(queue) == 0 {
+			continue
+		}
+		if !found || s.before(priority, next) {
+			next = priority
+			found = true
+		}
+	}
+	return next, found
+}
+
+// before reports whether class a goes before class b
+func (s *scheduler) before(a, b Priority) bool {
+	if s.pass[a] != s.pass[b] {
+		return s.pass[a] < s.pass[b]
+	}
+	if s.weight(a) != s.weight(b) {
+		return s.weight(a) > s.weight(b)
+	}
+	return a < b
+}
+
+// weight returns the weight of a class
+func (s *scheduler) weight(priority Priority) int {
+	if weight, ok := s.weights[priority]; ok {
+		return weight
+	}
+	return 1
+}
+
+// reserved reports whether a request must be refused to keep the daily reserve for high priority requests
+func (c *Client) reserved(req *Request) bool {
+	reserve := c.config.RateLimit.Priority.HighPriorityReserve
+	if reserve <= 0 || req.priority() == PriorityHigh {
+		return false
+	}
+
+	snapshot := c.rateLimiter.Snapshot()
+	if snapshot.DailyLimit <= 0 {
+		return false
+	}
+	return float64(snapshot.DailyRemaining) <= reserve*float64(snapshot.DailyLimit)
+}
+
+// priority returns the request's priority, defaulting to PriorityNormal
+func (r *Request) priority() Priority {
+	if r.Priority == "" {
+		return PriorityNormal
+	}
+	return r.Priority
+}
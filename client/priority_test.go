@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScheduler_WeightedFairOrder tests that waiting classes are granted in proportion to their weights
+func TestScheduler_WeightedFairOrder(t *testing.T) {
+	s := newScheduler(PriorityConfig{Weights: map[Priority]int{PriorityHigh: 2, PriorityLow: 1}})
+
+	// Hold the turn so every request below queues up
+	require.NoError(t, s.acquire(context.Background(), PriorityNormal))
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	enqueue := func(priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, s.acquire(context.Background(), priority))
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+			s.tokenTaken()
+			s.done()
+		}()
+	}
+	for range 4 {
+		enqueue(PriorityLow)
+		enqueue(PriorityHigh)
+	}
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.queues[PriorityHigh]) == 4 && len(s.queues[PriorityLow]) == 4
+	}, time.Second, time.Millisecond)
+
+	s.tokenTaken()
+	s.done()
+	wg.Wait()
+
+	assert.Equal(t, []Priority{
+		PriorityHigh, PriorityLow, PriorityHigh, PriorityHigh, PriorityLow, PriorityHigh, PriorityLow, PriorityLow,
+	}, order)
+}
+
+// TestScheduler_ContextCanceled tests that a canceled waiter leaves the queue
+func TestScheduler_ContextCanceled(t *testing.T) {
+	s := newScheduler(PriorityConfig{})
+	require.NoError(t, s.acquire(context.Background(), PriorityNormal))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.acquire(ctx, PriorityLow), context.DeadlineExceeded)
+
+	s.mu.Lock()
+	assert.Empty(t, s.queues[PriorityLow])
+	s.mu.Unlock()
+
+	s.tokenTaken()
+	s.done()
+	require.NoError(t, s.acquire(context.Background(), PriorityLow))
+}
+
+// TestPriorityScheduling_MaxInFlight tests that concurrent requests are bounded
+func TestPriorityScheduling_MaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		respondJSON(w, http.StatusOK, `{}`)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryEnabled(false),
+		WithPriorityScheduling(PriorityConfig{MaxInFlight: 2}),
+	)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+// TestPriorityScheduling_Policies tests that requests waiting on a rate limit policy are served in priority order
+func TestPriorityScheduling_Policies(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, r.Header.Get("X-Priority"))
+		mu.Unlock()
+		respondJSON(w, http.StatusOK, `{}`)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryEnabled(false),
+		WithRateLimitPolicy(SearchClass, RateLimitPolicy{MaxBurst: 1, Interval: 50 * time.Millisecond}),
+		WithPriorityScheduling(PriorityConfig{}),
+	)
+	require.NoError(t, err)
+
+	search := func(priority Priority) {
+		req := NewRequest(http.MethodPost, "/crm/v3/objects/contacts/search")
+		WithPriority(priority)(req)
+		WithHeader("X-Priority", string(priority))(req)
+		_, err := c.Do(context.Background(), req)
+		assert.NoError(t, err)
+	}
+	queued := func(priority Priority, n int) func() bool {
+		return func() bool {
+			c.scheduler.mu.Lock()
+			defer c.scheduler.mu.Unlock()
+			return len(c.scheduler.queues[priority]) == n
+		}
+	}
+
+	// The first search takes the bucket's token, the next low priority search holds the turn while it waits
+	search(PriorityLow)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() { search(PriorityLow) })
+	}
+	require.Eventually(t, queued(PriorityLow, 2), time.Second, time.Millisecond)
+	wg.Go(func() { search(PriorityHigh) })
+	require.Eventually(t, queued(PriorityHigh, 1), time.Second, time.Millisecond)
+	wg.Wait()
+
+	assert.Equal(t, []string{"low", "low", "high", "low", "low"}, order)
+}
+
+// TestPriorityScheduling_HighPriorityReserve tests that the reserved daily quota is kept for high priority requests
+func TestPriorityScheduling_HighPriorityReserve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-HubSpot-RateLimit-Daily", "1000")
+		w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "100")
+		respondJSON(w, http.StatusOK, `{}`)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryEnabled(false),
+		WithPriorityScheduling(PriorityConfig{HighPriorityReserve: 0.2}),
+	)
+	require.NoError(t, err)
+
+	// The first response reports that only the reserve is left
+	_, err = c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+	require.NoError(t, err)
+
+	req := NewRequest(http.MethodGet, "/test")
+	WithPriority(PriorityLow)(req)
+	_, err = c.Do(context.Background(), req)
+	var hubspotErr *HubSpotError
+	require.ErrorAs(t, err, &hubspotErr)
+	assert.Equal(t, http.StatusTooManyRequests, hubspotErr.Status)
+
+	req = NewRequest(http.MethodGet, "/test")
+	WithPriority(PriorityHigh)(req)
+	_, err = c.Do(context.Background(), req)
+	assert.NoError(t, err)
+}
+
+// TestWithPriorityScheduling tests priority config validation
+func TestWithPriorityScheduling(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PriorityConfig
+	}{
+		{"Zero weight", PriorityConfig{Weights: map[Priority]int{PriorityLow: 0}}},
+		{"Negative max in flight", PriorityConfig{MaxInFlight: -1}},
+		{"Reserve of the whole quota", PriorityConfig{HighPriorityReserve: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(WithPriorityScheduling(tt.config))
+			assert.Error(t, err)
+		})
+	}
+
+	c, err := NewClient(WithPriorityScheduling(PriorityConfig{}))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPriorityWeights(), c.scheduler.weights)
+}
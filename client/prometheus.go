@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the histogram buckets, in seconds, used by PrometheusMetrics
+var DefaultLatencyBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// PrometheusMetrics is an Instrumentation that aggregates events into counters, histograms and
+// quota gauges, and serves them in the Prometheus text exposition format
+//
+// Register it with WithInstrumentation and mount it on the metrics endpoint:
+//
+//	metrics := client.NewPrometheusMetrics()
+//	c, err := client.NewClient(client.WithInstrumentation(metrics))
+//	http.Handle("/metrics", metrics)
+type PrometheusMetrics struct {
+	NopInstrumentation
+
+	buckets []float64
+
+	mu         sync.Mutex
+	calls      map[callLabels]int
+	attempts   map[callLabels]int
+	retries    map[callLabels]int
+	latency    map[callLabels]*histogram
+	wait       map[callLabels]*histogram
+	reqBytes   map[callLabels]int
+	respBytes  map[callLabels]int
+	cache      map[callLabels]int
+	quota      RateLimitInfo
+	quotaKnown bool
+}
+
+// callLabels are the labels of every call metric
+type callLabels struct {
+	method       string
+	path         string
+	resourceType string
+	status       string
+	result       string
+}
+
+// histogram is a cumulative Prometheus histogram
+type histogram struct {
+	counts []int // per bucket, not cumulative
+	count  int
+	sum    float64
+}
+
+// NewPrometheusMetrics creates a PrometheusMetrics using DefaultLatencyBuckets
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		buckets:   DefaultLatencyBuckets,
+		calls:     make(map[callLabels]int),
+		attempts:  make(map[callLabels]int),
+		retries:   make(map[callLabels]int),
+		latency:   make(map[callLabels]*histogram),
+		wait:      make(map[callLabels]*histogram),
+		reqBytes:  make(map[callLabels]int),
+		respBytes: make(map[callLabels]int),
+		cache:     make(map[callLabels]int),
+	}
+}
+
+// EndCall implements Instrumentation
+func (m *PrometheusMetrics) EndCall(ctx context.Context, event CallEvent) {
+	labels := newCallLabels(event.RequestEvent)
+	// Histograms are keyed without the status so the number of series stays small
+	series := labels
+	series.status = ""
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls[labels]++
+	m.retries[series] += event.RetryCount
+	m.observe(m.latency, series, event.Latency.Seconds())
+	m.observe(m.wait, series, event.RateLimitWait.Seconds())
+	m.reqBytes[series] += event.RequestBytes
+	m.respBytes[series] += event.ResponseBytes
+}
+
+// EndAttempt implements Instrumentation
+func (m *PrometheusMetrics) EndAttempt(ctx context.Context, event AttemptEvent) {
+	labels := newCallLabels(event.RequestEvent)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.attempts[labels]++
+
+	// Like the rate limiter, keep the last known value for responses without rate limit headers
+	if event.RateLimit.DailyLimit > 0 {
+		m.quota.DailyLimit = event.RateLimit.DailyLimit
+		m.quota.DailyRemaining = event.RateLimit.DailyRemaining
+		m.quotaKnown = true
+	}
+	if event.RateLimit.Max > 0 {
+		m.quota.Max = event.RateLimit.Max
+		m.quota.Remaining = event.RateLimit.Remaining
+		m.quotaKnown = true
+	}
+}
+
+// ObserveCache implements CacheObserver
+func (m *PrometheusMetrics) ObserveCache(ctx context.Context, event CacheEvent) {
+	labels := callLabels{
+		method:       http.MethodGet,
+		path:         event.PathTemplate,
+		resourceType: event.ResourceType,
+		result:       string(event.Result),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cache[labels]++
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WriteText(w)
+}
+
+// WriteText writes the metrics in the Prometheus text exposition format
+func (m *PrometheusMetrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	var b strings.Builder
+	writeCounter(&b, "hubspot_api_calls_total", "Calls to the HubSpot API, including all of their attempts.", m.calls)
+	writeCounter(&b, "hubspot_api_attempts_total", "HTTP attempts made to the HubSpot API.", m.attempts)
+	writeCounter(&b, "hubspot_api_retries_total", "Attempts retried after a failed attempt.", m.retries)
+	writeCounter(&b, "hubspot_api_request_bytes_total", "Request body bytes sent to the HubSpot API.", m.reqBytes)
+	writeCounter(&b, "hubspot_api_response_bytes_total", "Response body bytes received from the HubSpot API.", m.respBytes)
+	writeCounter(&b, "hubspot_api_cache_requests_total", "Cacheable requests by cache result.", m.cache)
+	m.writeHistogram(&b, "hubspot_api_call_duration_seconds", "Latency of calls to the HubSpot API, including retries and rate limit waits.", m.latency)
+	m.writeHistogram(&b, "hubspot_api_rate_limit_wait_seconds", "Time calls spent waiting for the rate limiters.", m.wait)
+	if m.quotaKnown {
+		writeGauge(&b, "hubspot_api_daily_limit", "Daily request limit reported by HubSpot.", m.quota.DailyLimit)
+		writeGauge(&b, "hubspot_api_daily_remaining", "Daily requests remaining as reported by HubSpot.", m.quota.DailyRemaining)
+		writeGauge(&b, "hubspot_api_window_limit", "Requests allowed in the rolling rate limit window.", m.quota.Max)
+		writeGauge(&b, "hubspot_api_window_remaining", "Requests remaining in the rolling rate limit window.", m.quota.Remaining)
+	}
+	m.mu.Unlock()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// observe adds a value to the histogram for labels. m.mu must be held.
+func (m *PrometheusMetrics) observe(histograms map[callLabels]*histogram, labels callLabels, value float64) {
+	h, ok := histograms[labels]
+	if !ok {
+		h = &histogram{counts: make([]int, len(m.buckets))}
+		histograms[labels] = h
+	}
+
+	h.count++
+	h.sum += value
+	if i, _ := slices.BinarySearch(m.buckets, value); i < len(m.buckets) {
+		h.counts[i]++
+	}
+}
+
+// writeHistogram writes a histogram family. m.mu must be held.
+func (m *PrometheusMetrics) writeHistogram(b *strings.Builder, name, help string, histograms map[callLabels]*histogram) {
+	if len(histograms) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	for _, labels := range sortedLabels(histograms) {
+		h := histograms[labels]
+		cumulative := 0
+		for i, bound := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "%s_bucket%s %d\n", name, labels.format("le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", name, labels.format("le", "+Inf"), h.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", name, labels.format(), formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", name, labels.format(), h.count)
+	}
+}
+
+// writeCounter writes a counter family
+func writeCounter(b *strings.Builder, name, help string, counters map[callLabels]int) {
+	if len(counters) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	for _, labels := range sortedLabels(counters) {
+		fmt.Fprintf(b, "%s%s %d\n", name, labels.format(), counters[labels])
+	}
+}
+
+// writeGauge writes a gauge without labels
+func writeGauge(b *strings.Builder, name, help string, value int) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", name, help, name, name, value)
+}
+
+// newCallLabels returns the labels of an event
+func newCallLabels(event RequestEvent) callLabels {
+	labels := callLabels{
+		method:       event.Method,
+		path:         event.PathTemplate,
+		resourceType: event.ResourceType,
+		status:       strconv.Itoa(event.StatusCode),
+	}
+	if event.StatusCode == 0 {
+		labels.status = "error"
+	}
+	return labels
+}
+
+// format renders the labels, followed by extra name/value pairs, in exposition syntax
+func (l callLabels) format(extra ...string) string {
+	pairs := []string{
+		"method", l.method,
+		"path", l.path,
+		"resource_type", l.resourceType,
+	}
+	if l.status != "" {
+		pairs = append(pairs, "status", l.status)
+	}
+	if l.result != "" {
+		pairs = append(pairs, "result", l.result)
+	}
+	pairs = append(pairs, extra...)
+
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+`="`+escapeLabelValue(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// sortedLabels returns the keys of a metric family in a stable order
+func sortedLabels[V any](series map[callLabels]V) []callLabels {
+	keys := make([]callLabels, 0, len(series))
+	for labels := range series {
+		keys = append(keys, labels)
+	}
+	slices.SortFunc(keys, func(a, b callLabels) int {
+		return strings.Compare(a.format(), b.format())
+	})
+	return keys
+}
+
+// escapeLabelValue escapes backslashes, double quotes and newlines
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats a sample value
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// FileRateLimitStore is a RateLimitStore keeping each bucket in a file guarded by an exclusive file lock
+//
+// It coordinates processes on a single host sharing the same directory. File locks are only supported on unix systems.
+type FileRateLimitStore struct {
+	dir string
+}
+
+// NewFileRateLimitStore creates a FileRateLimitStore in dir, creating the directory if needed
+func NewFileRateLimitStore(dir string) (*FileRateLimitStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create rate limit store directory: %w", err)
+	}
+	return &FileRateLimitStore{
+		dir: dir,
+	}, nil
+}
+
+// Update implements RateLimitStore
+func (s *FileRateLimitStore) Update(ctx context.Context, key string, fn func(state BucketState) BucketState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, url.PathEscape(key)+".json")
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open rate limit bucket: %w", err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock rate limit bucket: %w", err)
+	}
+	defer func() {
+		_ = unlockFile(file)
+	}()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read rate limit bucket: %w", err)
+	}
+
+	// A new or corrupt bucket starts from the zero state
+	var state BucketState
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &state); err != nil {
+			state = BucketState{}
+		}
+	}
+
+	data, err = json.Marshal(fn(state))
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limit bucket: %w", err)
+	}
+	if err := file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write rate limit bucket: %w", err)
+	}
+	if _, err := file.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("failed to write rate limit bucket: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Endpoint classes with their own rate limit policies. Policies may also be keyed by Request.ResourceType.
+const (
+	SearchClass       = "search"
+	BatchClass        = "batch"
+	AssociationsClass = "associations"
+)
+
+// DefaultBucket is the general burst bucket every request draws from, sized by RateLimitConfig.MaxBurst
+const DefaultBucket = "default"
+
+// RateLimitPolicy limits requests of an endpoint class or resource type in addition to the general budget
+type RateLimitPolicy struct {
+	// Requests allowed per Interval
+	MaxBurst int
+	Interval time.Duration
+
+	// Bucket names the policy whose limits this policy draws from, so several classes can share one budget.
+	// Set it to DefaultBucket to only use the general budget. Empty isolates the policy in its own bucket.
+	Bucket string
+}
+
+// DefaultRateLimitPolicies returns HubSpot's documented limits: CRM search allows 5 requests per second,
+// while batch and association requests only count against the general budget
+func DefaultRateLimitPolicies() map[string]RateLimitPolicy {
+	return map[string]RateLimitPolicy{
+		SearchClass:       {MaxBurst: 5, Interval: time.Second},
+		BatchClass:        {Bucket: DefaultBucket},
+		AssociationsClass: {Bucket: DefaultBucket},
+	}
+}
+
+// EndpointClass returns the endpoint class of a request path, or DefaultBucket if it has no class
+func EndpointClass(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/crm/v3/objects/") && strings.HasSuffix(path, "/search"):
+		return SearchClass
+	case strings.HasPrefix(path, "/crm/v4/") && strings.Contains(path, "/associations"):
+		return AssociationsClass
+	case strings.Contains(path, "/batch/"):
+		return BatchClass
+	default:
+		return DefaultBucket
+	}
+}
+
+// policyLimiters holds one limiter per policy bucket
+type policyLimiters struct {
+	// buckets maps a policy key to the bucket it draws from
+	buckets  map[string]string
+	limiters map[string]Limiter
+}
+
+// newPolicyLimiters creates the limiters for every isolated bucket in the config
+func newPolicyLimiters(cfg RateLimitConfig) (*policyLimiters, error) {
+	p := &policyLimiters{
+		buckets:  make(map[string]string),
+		limiters: make(map[string]Limiter),
+	}
+
+	for key, policy := range cfg.Policies {
+		if key == DefaultBucket {
+			return nil, fmt.Errorf("rate limit policy %q is reserved for the general budget, use MaxBurst instead", DefaultBucket)
+		}
+
+		bucket := policy.Bucket
+		if bucket == "" {
+			bucket = key
+		}
+		if bucket == DefaultBucket {
+			continue
+		}
+
+		owner, ok := cfg.Policies[bucket]
+		if !ok || (owner.Bucket != "" && owner.Bucket != bucket) {
+			return nil, fmt.Errorf("rate limit policy %q draws from unknown bucket %q", key, bucket)
+		}
+		p.buckets[key] = bucket
+
+		if _, ok := p.limiters[bucket]; ok {
+			continue
+		}
+		if owner.MaxBurst <= 0 || owner.Interval <= 0 {
+			return nil, fmt.Errorf("rate limit policy %q must have a positive max burst and interval", bucket)
+		}
+
+		if cfg.Store != nil {
+			limiter, err := NewStoreLimiter(cfg.Store, cfg.StoreKey+":"+bucket, owner.MaxBurst, owner.Interval)
+			if err != nil {
+				return nil, err
+			}
+			p.limiters[bucket] = limiter
+		} else {
+			perSecond := float64(owner.MaxBurst) / owner.Interval.Seconds()
+			p.limiters[bucket] = rate.NewLimiter(rate.Limit(perSecond), owner.MaxBurst)
+		}
+	}
+
+	return p, nil
+}
+
+// Wait blocks until every policy bucket the request draws from allows it
+func (p *policyLimiters) Wait(ctx context.Context, req *Request) error {
+	keys := []string{req.ResourceType, EndpointClass(req.Path)}
+	if req.RateLimitClass != "" {
+		keys[1] = req.RateLimitClass
+	}
+
+	waited := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		bucket, ok := p.buckets[key]
+		if !ok || waited[bucket] {
+			continue
+		}
+		waited[bucket] = true
+
+		if err := p.limiters[bucket].Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEndpointClass tests classifying request paths
+func TestEndpointClass(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/crm/v3/objects/contacts/search", SearchClass},
+		{"/crm/v3/objects/2-123456/search", SearchClass},
+		{"/crm/v3/objects/deals/batch/read", BatchClass},
+		{"/crm/v4/associations/contacts/companies/batch/create", AssociationsClass},
+		{"/crm/v4/objects/contacts/1/associations/companies", AssociationsClass},
+		{"/crm/v3/objects/contacts/1", DefaultBucket},
+		{"/account-info/v3/details", DefaultBucket},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, EndpointClass(tc.path), tc.path)
+	}
+}
+
+// TestNewPolicyLimiters tests policy validation and bucket sharing
+func TestNewPolicyLimiters(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		p, err := newPolicyLimiters(NewConfig().RateLimit)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{SearchClass: SearchClass}, p.buckets)
+		assert.Len(t, p.limiters, 1)
+	})
+
+	t.Run("Shared bucket", func(t *testing.T) {
+		cfg := NewConfig().RateLimit
+		cfg.Policies[BatchClass] = RateLimitPolicy{Bucket: SearchClass}
+
+		p, err := newPolicyLimiters(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, SearchClass, p.buckets[BatchClass])
+		assert.Len(t, p.limiters, 1)
+	})
+
+	t.Run("Unknown bucket", func(t *testing.T) {
+		cfg := NewConfig().RateLimit
+		cfg.Policies[BatchClass] = RateLimitPolicy{Bucket: "missing"}
+
+		_, err := newPolicyLimiters(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Reserved default key", func(t *testing.T) {
+		cfg := NewConfig().RateLimit
+		cfg.Policies[DefaultBucket] = RateLimitPolicy{MaxBurst: 1, Interval: time.Second}
+
+		_, err := newPolicyLimiters(cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid limits", func(t *testing.T) {
+		cfg := NewConfig().RateLimit
+		cfg.Policies["contacts"] = RateLimitPolicy{MaxBurst: 0, Interval: time.Second}
+
+		_, err := newPolicyLimiters(cfg)
+		require.Error(t, err)
+	})
+}
+
+// TestRateLimitPolicies tests that requests draw from their class and resource buckets
+func TestRateLimitPolicies(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-HubSpot-RateLimit-Daily", "250000")
+		w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "249999")
+		respondJSON(w, http.StatusOK, `{}`)
+	})
+	defer server.Close()
+
+	client, err := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryEnabled(false),
+		WithRateLimitPolicy(SearchClass, RateLimitPolicy{MaxBurst: 1, Interval: time.Minute}),
+		WithRateLimitPolicy("tickets", RateLimitPolicy{MaxBurst: 1, Interval: time.Minute}),
+	)
+	require.NoError(t, err)
+
+	do := func(req *Request) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Do(ctx, req)
+		return err
+	}
+
+	// The search bucket allows one request per minute
+	require.NoError(t, do(NewRequest("POST", "/crm/v3/objects/contacts/search")))
+	require.Error(t, do(NewRequest("POST", "/crm/v3/objects/deals/search")))
+
+	// Other requests only draw from the general budget
+	require.NoError(t, do(NewRequest("GET", "/crm/v3/objects/contacts/1")))
+	require.NoError(t, do(NewRequest("GET", "/crm/v3/objects/contacts/2")))
+
+	// Resource type policies apply to every endpoint of the resource
+	require.NoError(t, do(NewRequest("GET", "/crm/v3/objects/tickets/1").WithResourceType("tickets")))
+	require.Error(t, do(NewRequest("GET", "/crm/v3/objects/tickets/2").WithResourceType("tickets")))
+
+	// The class can be overridden per request
+	require.Error(t, do(NewRequest("GET", "/crm/v3/objects/contacts/3").WithRateLimitClass(SearchClass)))
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BucketState is the token bucket state persisted in a RateLimitStore
+type BucketState struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// RateLimitStore persists token bucket state so several processes can share one rate limit budget
+//
+// Implementations must be safe for concurrent use by multiple goroutines and, for shared stores, multiple processes
+type RateLimitStore interface {
+	// Update atomically reads the bucket stored under key, passes it to fn and stores the returned state.
+	// A zero BucketState is passed to fn if nothing is stored under key.
+	Update(ctx context.Context, key string, fn func(state BucketState) BucketState) error
+}
+
+// StoreLimiter is a token bucket Limiter whose state is kept in a RateLimitStore
+//
+// Every StoreLimiter using the same store and key draws from the same budget.
+type StoreLimiter struct {
+	store RateLimitStore
+	key   string
+
+	mu       sync.RWMutex
+	burst    float64
+	perToken time.Duration
+}
+
+// NewStoreLimiter creates a limiter allowing maxBurst requests per interval, shared through store under key
+func NewStoreLimiter(store RateLimitStore, key string, maxBurst int, interval time.Duration) (*StoreLimiter, error) {
+	if store == nil {
+		return nil, fmt.Errorf("rate limit store cannot be nil")
+	}
+	if key == "" {
+		return nil, fmt.Errorf("rate limit store key cannot be empty")
+	}
+	if maxBurst <= 0 || interval <= 0 {
+		return nil, fmt.Errorf("rate limit max burst and interval must be positive")
+	}
+
+	return &StoreLimiter{
+		store:    store,
+		key:      key,
+		burst:    float64(maxBurst),
+		perToken: interval / time.Duration(maxBurst),
+	}, nil
+}
+
+// Wait implements Limiter
+func (l *StoreLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := l.take(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to take rate limit token: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Adapt implements AdaptiveLimiter
+//
+// The shared bucket is clamped to the remaining budget reported by HubSpot, which accounts for consumers outside the store
+func (l *StoreLimiter) Adapt(info RateLimitInfo) {
+	if info.Max <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	l.burst = float64(info.Max)
+	l.perToken = time.Duration(float64(time.Second) / pacedRate(info))
+	l.mu.Unlock()
+
+	remaining := float64(info.Remaining)
+	_ = l.store.Update(context.Background(), l.key, func(state BucketState) BucketState {
+		if !state.UpdatedAt.IsZero() && state.Tokens > remaining {
+			state.Tokens = remaining
+		}
+		return state
+	})
+}
+
+// take attempts to take a token, returning how long to wait before trying again if none is available
+func (l *StoreLimiter) take(ctx context.Context) (time.Duration, error) {
+	l.mu.RLock()
+	burst, perToken := l.burst, l.perToken
+	l.mu.RUnlock()
+
+	var wait time.Duration
+	err := l.store.Update(ctx, l.key, func(state BucketState) BucketState {
+		now := time.Now()
+		if state.UpdatedAt.IsZero() {
+			state.Tokens = burst
+		} else if elapsed := now.Sub(state.UpdatedAt); elapsed > 0 {
+			// Clocks of processes sharing a bucket may be skewed, so time never runs backwards here
+			state.Tokens = min(burst, state.Tokens+float64(elapsed)/float64(perToken))
+		}
+		if now.After(state.UpdatedAt) {
+			state.UpdatedAt = now
+		}
+
+		if state.Tokens >= 1 {
+			state.Tokens--
+			wait = 0
+		} else {
+			wait = time.Duration((1 - state.Tokens) * float64(perToken))
+		}
+		return state
+	})
+
+	return wait, err
+}
+
+// MemoryRateLimitStore is an in-process RateLimitStore, useful for tests
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]BucketState
+}
+
+// NewMemoryRateLimitStore creates an empty MemoryRateLimitStore
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]BucketState),
+	}
+}
+
+// Update implements RateLimitStore
+func (s *MemoryRateLimitStore) Update(ctx context.Context, key string, fn func(state BucketState) BucketState) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets[key] = fn(s.buckets[key])
+	return nil
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStoreLimiter tests token bucket behavior on a shared store
+func TestStoreLimiter(t *testing.T) {
+	t.Run("Limiters on the same key share one budget", func(t *testing.T) {
+		store := NewMemoryRateLimitStore()
+		first, err := NewStoreLimiter(store, "portal-1", 5, 10*time.Second)
+		require.NoError(t, err)
+		second, err := NewStoreLimiter(store, "portal-1", 5, 10*time.Second)
+		require.NoError(t, err)
+
+		for range 3 {
+			require.NoError(t, first.Wait(context.Background()))
+		}
+		for range 2 {
+			require.NoError(t, second.Wait(context.Background()))
+		}
+
+		// The shared bucket is now empty and refills one token every 2 seconds
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, second.Wait(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("Different keys have separate budgets", func(t *testing.T) {
+		store := NewMemoryRateLimitStore()
+		first, err := NewStoreLimiter(store, "portal-1", 1, 10*time.Second)
+		require.NoError(t, err)
+		second, err := NewStoreLimiter(store, "portal-2", 1, 10*time.Second)
+		require.NoError(t, err)
+
+		require.NoError(t, first.Wait(context.Background()))
+		require.NoError(t, second.Wait(context.Background()))
+	})
+
+	t.Run("Waits for refill", func(t *testing.T) {
+		store := NewMemoryRateLimitStore()
+		limiter, err := NewStoreLimiter(store, "portal-1", 1, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		require.NoError(t, limiter.Wait(context.Background()))
+		start := time.Now()
+		require.NoError(t, limiter.Wait(context.Background()))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		_, err := NewStoreLimiter(nil, "key", 1, time.Second)
+		require.Error(t, err)
+		_, err = NewStoreLimiter(NewMemoryRateLimitStore(), "", 1, time.Second)
+		require.Error(t, err)
+		_, err = NewStoreLimiter(NewMemoryRateLimitStore(), "key", 0, time.Second)
+		require.Error(t, err)
+	})
+}
+
+// TestFileRateLimitStore tests the file-lock based store
+func TestFileRateLimitStore(t *testing.T) {
+	dir := t.TempDir()
+
+	// Separate store instances stand in for separate processes sharing the directory
+	var wg sync.WaitGroup
+	for range 4 {
+		store, err := NewFileRateLimitStore(dir)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				err := store.Update(context.Background(), "portal/1", func(state BucketState) BucketState {
+					state.Tokens++
+					return state
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	store, err := NewFileRateLimitStore(dir)
+	require.NoError(t, err)
+	var final BucketState
+	require.NoError(t, store.Update(context.Background(), "portal/1", func(state BucketState) BucketState {
+		final = state
+		return state
+	}))
+	assert.InDelta(t, 100, final.Tokens, 0)
+}
+
+// TestClient_RateLimitStore tests clients sharing a budget through a store
+func TestClient_RateLimitStore(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-HubSpot-RateLimit-Daily", "250000")
+		w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "249999")
+		respondJSON(w, http.StatusOK, `{}`)
+	})
+	defer server.Close()
+
+	store := NewMemoryRateLimitStore()
+	newClient := func() *Client {
+		c, err := NewClient(
+			WithBaseURL(server.URL),
+			WithRetryEnabled(false),
+			WithRateLimitMaxBurst(2),
+			WithRateLimitStore(store, "portal-1"),
+		)
+		require.NoError(t, err)
+		return c
+	}
+	first, second := newClient(), newClient()
+
+	_, err := first.Do(context.Background(), NewRequest("GET", "/test"))
+	require.NoError(t, err)
+	_, err = second.Do(context.Background(), NewRequest("GET", "/test"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = first.Do(ctx, NewRequest("GET", "/test"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
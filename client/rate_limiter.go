@@ -2,32 +2,70 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
-
-	"golang.org/x/time/rate"
+	"unicode"
 )
 
+// Limiter limits the rate of requests within HubSpot's burst window
+//
+// *rate.Limiter from golang.org/x/time/rate satisfies this interface. Implementations must be safe for concurrent use.
+type Limiter interface {
+	// Wait blocks until a request may be made or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// QuotaSnapshot is the rate limit state as of the last response
+type QuotaSnapshot struct {
+	DailyLimit     int
+	DailyRemaining int
+	DailyUsed      int
+	DailyResetTime time.Time
+
+	// Fraction of the daily limit used, between 0 and 1
+	DailyUsedFraction float64
+}
+
+// QuotaThresholdFunc is called once per day for every configured threshold the daily usage crosses
+type QuotaThresholdFunc func(threshold float64, snapshot QuotaSnapshot)
+
 type RateLimiter struct {
-	limiter *rate.Limiter // golang.org/x/time/rate
+	limiter Limiter
 	mu      sync.RWMutex
 
 	// Track daily usage (resets at account's midnight)
 	dailyLimit     int
 	dailyRemaining int
 	dailyResetTime time.Time
+	location       *time.Location
+
+	// Ascending usage fractions that trigger onThreshold, and the index of the next one to fire today
+	thresholds    []float64
+	nextThreshold int
+	onThreshold   QuotaThresholdFunc
 }
 
 // NewRateLimiter manages rate limiting for API requests
+//
+// The burst limiter starts at maxBurst requests per 10 seconds and retunes itself from the response headers
 func NewRateLimiter(maxBurst int) *RateLimiter {
-	// Convert requests per 10 seconds to per-second rate
-	requestsPerSecond := float64(maxBurst) / 10.0
+	return NewRateLimiterWithLimiter(newAdaptiveRateLimiter(maxBurst))
+}
 
+// NewRateLimiterWithLimiter manages rate limiting for API requests using the given burst limiter,
+// e.g. a StoreLimiter shared between processes. Limiters implementing AdaptiveLimiter are retuned from response headers.
+//
+// The daily quota resets at midnight UTC until SetResetLocation is called with the account's time zone.
+func NewRateLimiterWithLimiter(limiter Limiter) *RateLimiter {
 	return &RateLimiter{
-		limiter:        rate.NewLimiter(rate.Limit(requestsPerSecond), maxBurst),
+		limiter:        limiter,
 		dailyLimit:     250000,
 		dailyRemaining: 250000,
-		dailyResetTime: time.Now().AddDate(0, 0, 1),
+		dailyResetTime: nextMidnight(time.Now(), time.UTC),
+		location:       time.UTC,
 	}
 }
 
@@ -36,23 +74,96 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return rl.limiter.Wait(ctx)
 }
 
+// SetDailyLimit sets the daily limit assumed until a response reports it
+func (rl *RateLimiter) SetDailyLimit(limit int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.dailyLimit = limit
+	rl.dailyRemaining = limit
+}
+
+// SetResetLocation sets the time zone whose midnight resets the daily quota, i.e. the account's time zone
+func (rl *RateLimiter) SetResetLocation(loc *time.Location) {
+	if loc == nil {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.location = loc
+	rl.dailyResetTime = nextMidnight(time.Now(), loc)
+}
+
+// SetQuotaThresholds registers fn to be called when the daily usage crosses each of the thresholds,
+// given as fractions of the daily limit (e.g. 0.8 and 0.95). fn is called synchronously and should not block.
+func (rl *RateLimiter) SetQuotaThresholds(fn QuotaThresholdFunc, thresholds ...float64) {
+	sorted := slices.Clone(thresholds)
+	slices.Sort(sorted)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.thresholds = sorted
+	rl.onThreshold = fn
+	rl.nextThreshold = 0
+}
+
 // UpdateFromResponse updates the rate limiter state from response headers
+//
+// Responses without daily headers, e.g. for OAuth apps, leave the daily quota unchanged
 func (rl *RateLimiter) UpdateFromResponse(resp *Response) {
+	if adaptive, ok := rl.limiter.(AdaptiveLimiter); ok {
+		adaptive.Adapt(resp.RateLimit)
+	}
+
 	rl.mu.Lock()
-	defer rl.mu.Unlock()
+	rl.resetIfDueLocked(time.Now())
+	resp.RateLimit.DailyResetTime = rl.dailyResetTime
 
+	if resp.RateLimit.DailyLimit <= 0 {
+		rl.mu.Unlock()
+		return
+	}
 	rl.dailyRemaining = resp.RateLimit.DailyRemaining
 	rl.dailyLimit = resp.RateLimit.DailyLimit
+
+	// Collect crossed thresholds under the lock, but call back outside of it
+	snapshot := rl.snapshotLocked()
+	var crossed []float64
+	for rl.nextThreshold < len(rl.thresholds) && snapshot.DailyUsedFraction >= rl.thresholds[rl.nextThreshold] {
+		crossed = append(crossed, rl.thresholds[rl.nextThreshold])
+		rl.nextThreshold++
+	}
+	onThreshold := rl.onThreshold
+	rl.mu.Unlock()
+
+	if onThreshold != nil {
+		for _, threshold := range crossed {
+			onThreshold(threshold, snapshot)
+		}
+	}
 }
 
 // CheckDailyLimit returns true if daily quota is available
 func (rl *RateLimiter) CheckDailyLimit() bool {
-	rl.mu.RLock()
-	defer rl.mu.RUnlock()
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
 
+	rl.resetIfDueLocked(time.Now())
 	return rl.dailyRemaining > 0
 }
 
+// Snapshot returns the current quota state
+func (rl *RateLimiter) Snapshot() QuotaSnapshot {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.resetIfDueLocked(time.Now())
+	return rl.snapshotLocked()
+}
+
 // GetDailyLimit returns the current daily limit received from the API response
 func (rl *RateLimiter) GetDailyLimit() int {
 	rl.mu.RLock()
@@ -76,3 +187,74 @@ func (rl *RateLimiter) GetDailyResetTime() time.Time {
 
 	return rl.dailyResetTime
 }
+
+// resetIfDueLocked restores the daily quota once the reset time has passed. rl.mu must be held.
+func (rl *RateLimiter) resetIfDueLocked(now time.Time) {
+	if now.Before(rl.dailyResetTime) {
+		return
+	}
+	rl.dailyRemaining = rl.dailyLimit
+	rl.dailyResetTime = nextMidnight(now, rl.location)
+	rl.nextThreshold = 0
+}
+
+// snapshotLocked returns the current quota state. rl.mu must be held.
+func (rl *RateLimiter) snapshotLocked() QuotaSnapshot {
+	snapshot := QuotaSnapshot{
+		DailyLimit:     rl.dailyLimit,
+		DailyRemaining: rl.dailyRemaining,
+		DailyUsed:      max(rl.dailyLimit-rl.dailyRemaining, 0),
+		DailyResetTime: rl.dailyResetTime,
+	}
+	if rl.dailyLimit > 0 {
+		snapshot.DailyUsedFraction = float64(snapshot.DailyUsed) / float64(rl.dailyLimit)
+	}
+	return snapshot
+}
+
+// nextMidnight returns the first midnight after now in loc
+func nextMidnight(now time.Time, loc *time.Location) time.Time {
+	year, month, day := now.In(loc).Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
+
+// LoadAccountLocation loads the time zone reported by the account info API.
+// HubSpot reports IANA names in lower case (e.g. "us/eastern"), so common capitalizations are tried.
+func LoadAccountLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("time zone name cannot be empty")
+	}
+
+	segments := strings.Split(name, "/")
+	capitalized := make([]string, len(segments))
+	for i, segment := range segments {
+		capitalized[i] = capitalizeWords(segment)
+	}
+
+	candidates := []string{
+		name,
+		strings.Join(capitalized, "/"),
+		// e.g. US/Eastern
+		strings.Join(append([]string{strings.ToUpper(segments[0])}, capitalized[1:]...), "/"),
+		// e.g. Etc/GMT+5
+		strings.Join(append([]string{capitalized[0]}, strings.ToUpper(strings.Join(segments[1:], "/"))), "/"),
+	}
+
+	for _, candidate := range candidates {
+		if loc, err := time.LoadLocation(candidate); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown time zone %q", name)
+}
+
+// capitalizeWords upper-cases the first letter of every word separated by '_' or '-'
+func capitalizeWords(s string) string {
+	b := []byte(strings.ToLower(s))
+	for i := range b {
+		if i == 0 || b[i-1] == '_' || b[i-1] == '-' {
+			b[i] = byte(unicode.ToUpper(rune(b[i])))
+		}
+	}
+	return string(b)
+}
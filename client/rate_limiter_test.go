@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRateLimiter_DailyQuota tests daily quota tracking across responses and resets
+func TestRateLimiter_DailyQuota(t *testing.T) {
+	t.Run("Responses without daily headers are ignored", func(t *testing.T) {
+		rl := NewRateLimiter(100)
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{DailyLimit: 1000, DailyRemaining: 900}})
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{Max: 100, Remaining: 50}})
+
+		assert.Equal(t, 1000, rl.GetDailyLimit())
+		assert.Equal(t, 900, rl.GetDailyRemaining())
+		assert.True(t, rl.CheckDailyLimit())
+	})
+
+	t.Run("Quota resets after midnight", func(t *testing.T) {
+		rl := NewRateLimiter(100)
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{DailyLimit: 1000, DailyRemaining: 0}})
+		require.False(t, rl.CheckDailyLimit())
+
+		rl.mu.Lock()
+		rl.dailyResetTime = time.Now().Add(-time.Second)
+		rl.mu.Unlock()
+
+		assert.True(t, rl.CheckDailyLimit())
+		assert.Equal(t, 1000, rl.GetDailyRemaining())
+		assert.True(t, rl.GetDailyResetTime().After(time.Now()))
+	})
+
+	t.Run("Resets at the account's midnight", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		rl := NewRateLimiter(100)
+		rl.SetResetLocation(loc)
+
+		reset := rl.GetDailyResetTime().In(loc)
+		assert.Equal(t, 0, reset.Hour())
+		assert.Equal(t, 0, reset.Minute())
+		assert.True(t, reset.After(time.Now()))
+		assert.LessOrEqual(t, time.Until(reset), 25*time.Hour)
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		rl := NewRateLimiter(100)
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{DailyLimit: 1000, DailyRemaining: 250}})
+
+		snapshot := rl.Snapshot()
+		assert.Equal(t, 1000, snapshot.DailyLimit)
+		assert.Equal(t, 250, snapshot.DailyRemaining)
+		assert.Equal(t, 750, snapshot.DailyUsed)
+		assert.InDelta(t, 0.75, snapshot.DailyUsedFraction, 1e-9)
+		assert.Equal(t, rl.GetDailyResetTime(), snapshot.DailyResetTime)
+	})
+}
+
+// TestRateLimiter_QuotaThresholds tests threshold callbacks fire once per threshold per day
+func TestRateLimiter_QuotaThresholds(t *testing.T) {
+	var fired []float64
+	rl := NewRateLimiter(100)
+	rl.SetQuotaThresholds(func(threshold float64, snapshot QuotaSnapshot) {
+		fired = append(fired, threshold)
+		assert.GreaterOrEqual(t, snapshot.DailyUsedFraction, threshold)
+	}, 0.95, 0.8)
+
+	update := func(remaining int) {
+		rl.UpdateFromResponse(&Response{RateLimit: RateLimitInfo{DailyLimit: 100, DailyRemaining: remaining}})
+	}
+
+	update(50)
+	assert.Empty(t, fired)
+
+	update(20)
+	assert.Equal(t, []float64{0.8}, fired)
+
+	update(19)
+	assert.Equal(t, []float64{0.8}, fired)
+
+	// Crossing several thresholds at once fires each of them
+	rl.SetQuotaThresholds(func(threshold float64, snapshot QuotaSnapshot) {
+		fired = append(fired, threshold)
+	}, 0.5, 0.9)
+	fired = nil
+	update(5)
+	assert.Equal(t, []float64{0.5, 0.9}, fired)
+
+	// Thresholds fire again after the daily reset
+	rl.mu.Lock()
+	rl.dailyResetTime = time.Now().Add(-time.Second)
+	rl.mu.Unlock()
+	fired = nil
+	update(5)
+	assert.Equal(t, []float64{0.5, 0.9}, fired)
+}
+
+// TestLoadAccountLocation tests parsing the time zone names reported by the account info API
+func TestLoadAccountLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"us/eastern", "US/Eastern"},
+		{"america/new_york", "America/New_York"},
+		{"America/Los_Angeles", "America/Los_Angeles"},
+		{"europe/london", "Europe/London"},
+		{"etc/gmt+5", "Etc/GMT+5"},
+		{"utc", "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadAccountLocation(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, loc.String())
+		})
+	}
+
+	t.Run("Unknown time zone", func(t *testing.T) {
+		_, err := LoadAccountLocation("not/a_zone")
+		require.Error(t, err)
+	})
+}
+
+// TestClient_QuotaThresholds tests threshold options are applied to the client's rate limiter
+func TestClient_QuotaThresholds(t *testing.T) {
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-HubSpot-RateLimit-Daily", "1000")
+		w.Header().Set("X-HubSpot-RateLimit-Daily-Remaining", "100")
+		respondJSON(w, http.StatusOK, `{}`)
+	})
+	defer server.Close()
+
+	var fired []float64
+	c, err := NewClient(
+		WithBaseURL(server.URL),
+		WithAccountTimeZone("us/eastern"),
+		WithQuotaThresholds(func(threshold float64, snapshot QuotaSnapshot) {
+			fired = append(fired, threshold)
+		}, 0.8, 0.95),
+	)
+	require.NoError(t, err)
+
+	_, err = c.Do(context.Background(), NewRequest("GET", "/test"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{0.8}, fired)
+	snapshot := c.QuotaSnapshot()
+	assert.Equal(t, 900, snapshot.DailyUsed)
+	assert.Equal(t, "US/Eastern", snapshot.DailyResetTime.Location().String())
+
+	_, err = NewClient(WithQuotaThresholds(func(float64, QuotaSnapshot) {}, 1.5))
+	require.Error(t, err)
+	_, err = NewClient(WithAccountTimeZone("not/a_zone"))
+	require.Error(t, err)
+}
@@ -0,0 +1,305 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder records or replays
+type RecorderMode int
+
+const (
+	// ReplayMode serves recorded interactions and fails on requests that weren't recorded
+	ReplayMode RecorderMode = iota
+	// RecordMode sends requests to HubSpot and records every interaction to the cassette
+	RecordMode
+)
+
+// Cassette is the file format of recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response HubSpot returned for it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a scrubbed request. Method, path, query and body are matched on replay.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// UnmatchedRequestError is returned in replay mode for a request that has no unused recorded interaction
+type UnmatchedRequestError struct {
+	Cassette string
+	Request  RecordedRequest
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	target := e.Request.Path
+	if e.Request.Query != "" {
+		target += "?" + e.Request.Query
+	}
+	msg := fmt.Sprintf("no recorded interaction in %s matches %s %s", e.Cassette, e.Request.Method, target)
+	if e.Request.Body != "" {
+		msg += " with body " + e.Request.Body
+	}
+	return msg
+}
+
+// RecorderConfig configures a Recorder
+type RecorderConfig struct {
+	Mode RecorderMode
+
+	// Path is the cassette file. It must exist in replay mode and is overwritten in record mode.
+	Path string
+
+	// Transport sends requests in record mode, defaults to http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Redaction scrubs credentials and PII before interactions are written, defaults to DefaultRedaction
+	Redaction *Redaction
+}
+
+// Recorder is an http.RoundTripper that records HubSpot traffic to a cassette file and replays it in tests
+//
+// Inject it with WithTransport:
+//
+//	recorder, err := client.NewRecorder(client.RecorderConfig{Path: "testdata/contacts.json"})
+//	c, err := client.NewClient(client.WithTransport(recorder), client.WithAccessToken("test"))
+//
+// Requests are scrubbed with the same rules whether recorded or replayed, so replayed requests
+// match their recordings even though secrets and PII never reach the cassette.
+type Recorder struct {
+	config   RecorderConfig
+	redactor *redactor
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder, loading the cassette in replay mode
+func NewRecorder(cfg RecorderConfig) (*Recorder, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("cassette path cannot be empty")
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+	redaction := DefaultRedaction()
+	if cfg.Redaction != nil {
+		redaction = *cfg.Redaction
+	}
+
+	r := &Recorder{
+		config:   cfg,
+		redactor: newRedactor(redaction),
+	}
+
+	switch cfg.Mode {
+	case ReplayMode:
+		data, err := os.ReadFile(cfg.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("cassette %s does not exist, record it first: %w", cfg.Path, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cassette %s: %w", cfg.Path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case RecordMode:
+	default:
+		return nil, fmt.Errorf("invalid recorder mode: %d", cfg.Mode)
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := r.scrubRequest(req, body)
+
+	if r.config.Mode == ReplayMode {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, body, recorded)
+}
+
+// Unused returns the recorded interactions that haven't been replayed, e.g. to assert that a test made every expected request
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// replay serves the first unused interaction matching the request
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	return nil, &UnmatchedRequestError{Cassette: r.config.Path, Request: recorded}
+}
+
+// record sends the request and appends the scrubbed interaction to the cassette file
+func (r *Recorder) record(req *http.Request, body []byte, recorded RecordedRequest) (*http.Response, error) {
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.config.Transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	// Cassettes hold decompressed bodies so they can be scrubbed and read
+	respBody, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(respBody))
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactor.redactHeaders(resp.Header),
+			Body:       r.scrubBody(resp.Header.Get("Content-Type"), respBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.saveLocked(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// saveLocked writes the cassette file. r.mu must be held.
+func (r *Recorder) saveLocked() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.config.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.config.Path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// scrubRequest returns the scrubbed form of a request used for recording and matching
+func (r *Recorder) scrubRequest(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method:  req.Method,
+		Path:    r.redactor.redactPath(req.URL.Path),
+		Query:   r.redactor.redactQuery(req.URL.Query()),
+		Headers: r.redactor.redactHeaders(req.Header),
+		Body:    r.scrubBody(req.Header.Get("Content-Type"), body),
+	}
+}
+
+// scrubBody redacts a JSON or form body. JSON is re-encoded with sorted keys so equal bodies compare equal.
+func (r *Recorder) scrubBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return r.redactor.redactQuery(values)
+		}
+	}
+	if redacted, err := r.redactor.redactBody(body); err == nil {
+		return string(redacted)
+	}
+	return r.redactor.redactString(string(body))
+}
+
+// matches reports whether a recorded request matches an incoming one
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method && rr.Path == other.Path && rr.Query == other.Query && rr.Body == other.Body
+}
+
+// httpResponse builds the response served on replay
+func (rr RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	headers := rr.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads and closes the body of an outgoing request
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return body, nil
+}
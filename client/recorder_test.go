@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecorder tests recording HubSpot traffic and replaying it without a server
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "testdata", "contacts.json")
+
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == "GET":
+			// Compressed bodies are recorded decompressed so they can be scrubbed
+			respondGzip(t, w, http.StatusOK, `{"id": "1", "properties": {"email": "jane@example.com", "firstname": "Jane"}}`)
+		case r.Method == "POST" && len(body) > 0:
+			respondJSON(w, http.StatusCreated, `{"id": "2"}`)
+		default:
+			respondJSON(w, http.StatusBadRequest, `{}`)
+		}
+	})
+	defer server.Close()
+
+	recordCall := func(c *Client) {
+		resp, err := c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/contacts/1").AddQueryParam("properties", "email,firstname"))
+		require.NoError(t, err)
+		assert.Contains(t, string(resp.Body), "Jane")
+
+		resp, err = c.Do(context.Background(), NewRequest("POST", "/crm/v3/objects/contacts").WithBody(map[string]any{
+			"properties": map[string]string{"email": "john@example.com", "firstname": "John"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	// Record against the server
+	recorder, err := NewRecorder(RecorderConfig{Mode: RecordMode, Path: cassette})
+	require.NoError(t, err)
+	c, err := NewClient(WithBaseURL(server.URL), WithAccessToken("live-token"), WithTransport(recorder))
+	require.NoError(t, err)
+	recordCall(c)
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	for _, secret := range []string{"live-token", "jane@example.com", "john@example.com"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	var recorded Cassette
+	require.NoError(t, json.Unmarshal(data, &recorded))
+	require.Len(t, recorded.Interactions, 2)
+	assert.Equal(t, "/crm/v3/objects/contacts/1", recorded.Interactions[0].Request.Path)
+
+	// Replay without the server, with a different token
+	server.Close()
+	replayer, err := NewRecorder(RecorderConfig{Path: cassette})
+	require.NoError(t, err)
+	c, err = NewClient(WithBaseURL(server.URL), WithAccessToken("test-token"), WithTransport(replayer), WithRetryEnabled(false))
+	require.NoError(t, err)
+	recordCall(c)
+	assert.Empty(t, replayer.Unused())
+
+	// Interactions are served once, so a further request is unmatched
+	_, err = c.Do(context.Background(), NewRequest("GET", "/crm/v3/objects/contacts/1").AddQueryParam("properties", "email,firstname"))
+	var unmatched *UnmatchedRequestError
+	require.ErrorAs(t, err, &unmatched)
+	assert.Equal(t, "GET", unmatched.Request.Method)
+	assert.Contains(t, err.Error(), "/crm/v3/objects/contacts/1?properties=email%2Cfirstname")
+}
+
+// TestRecorder_Matching tests that replay matches method, path, query and body
+func TestRecorder_Matching(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	data, err := json.Marshal(Cassette{Interactions: []Interaction{
+		{
+			Request:  RecordedRequest{Method: "POST", Path: "/crm/v3/objects/deals/search", Body: `{"limit":10,"query":"big"}`},
+			Response: RecordedResponse{StatusCode: 200, Body: `{"total": 1}`},
+		},
+		{
+			Request:  RecordedRequest{Method: "GET", Path: "/crm/v3/objects/deals", Query: "limit=5"},
+			Response: RecordedResponse{StatusCode: 200, Body: `{"results": []}`},
+		},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cassette, data, 0o644))
+
+	newClient := func() *Client {
+		recorder, err := NewRecorder(RecorderConfig{Path: cassette})
+		require.NoError(t, err)
+		c, err := NewClient(WithTransport(recorder), WithRetryEnabled(false))
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("Equal JSON bodies match regardless of key order", func(t *testing.T) {
+		resp, err := newClient().Do(context.Background(), NewRequest("POST", "/crm/v3/objects/deals/search").WithBody(`{"query": "big", "limit": 10}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"total": 1}`, string(resp.Body))
+	})
+
+	for name, req := range map[string]*Request{
+		"Different body":   NewRequest("POST", "/crm/v3/objects/deals/search").WithBody(`{"query": "small", "limit": 10}`),
+		"Different query":  NewRequest("GET", "/crm/v3/objects/deals").AddQueryParam("limit", "10"),
+		"Different method": NewRequest("DELETE", "/crm/v3/objects/deals"),
+		"Different path":   NewRequest("GET", "/crm/v3/objects/contacts").AddQueryParam("limit", "5"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newClient().Do(context.Background(), req)
+			assert.ErrorAs(t, err, new(*UnmatchedRequestError))
+		})
+	}
+
+	t.Run("Missing cassette", func(t *testing.T) {
+		_, err := NewRecorder(RecorderConfig{Path: filepath.Join(t.TempDir(), "missing.json")})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+// TestRecorder_TokenPaths tests that OAuth tokens in request paths are not recorded
+func TestRecorder_TokenPaths(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "oauth.json")
+	const token = "CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf3xBKmlwHjX7OIpuIFEavB2-qYAGQsF4"
+
+	server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth/v1/access-tokens/"+token, r.URL.Path)
+		respondJSON(w, http.StatusOK, `{"hub_id": 62515, "user": "jane@example.com", "token_type": "access"}`)
+	})
+	defer server.Close()
+
+	recorder, err := NewRecorder(RecorderConfig{Mode: RecordMode, Path: cassette})
+	require.NoError(t, err)
+	c, err := NewClient(WithBaseURL(server.URL), WithTransport(recorder))
+	require.NoError(t, err)
+	_, err = c.Do(context.Background(), NewRequest("GET", "/oauth/v1/access-tokens/"+token))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), token)
+	var recorded Cassette
+	require.NoError(t, json.Unmarshal(data, &recorded))
+	require.Len(t, recorded.Interactions, 1)
+	assert.Equal(t, "/oauth/v1/access-tokens/"+redactedValue, recorded.Interactions[0].Request.Path)
+
+	// Replay matches any token
+	server.Close()
+	replayer, err := NewRecorder(RecorderConfig{Path: cassette})
+	require.NoError(t, err)
+	c, err = NewClient(WithBaseURL(server.URL), WithTransport(replayer), WithRetryEnabled(false))
+	require.NoError(t, err)
+	resp, err := c.Do(context.Background(), NewRequest("GET", "/oauth/v1/access-tokens/other-token"))
+	require.NoError(t, err)
+	assert.Contains(t, string(resp.Body), "62515")
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces every redacted header, field and value
+const redactedValue = "[REDACTED]"
+
+// Redaction configures how credentials and PII are scrubbed from logs and recorded cassettes
+type Redaction struct {
+	// RedactHeaders are header names whose values are always redacted, in addition to the credential headers
+	// Authorization, Proxy-Authorization, Cookie and Set-Cookie, which are redacted even when it is empty
+	RedactHeaders []string
+
+	// RedactFields are JSON field and query parameter names whose values are always redacted.
+	// A name matches every key containing it, case-insensitively, so "email" also redacts "hs_additional_emails".
+	RedactFields []string
+
+	// RedactEmails redacts every string that looks like an email address, e.g. in search filters
+	RedactEmails bool
+}
+
+// DefaultRedaction redacts credentials, email addresses and phone numbers
+func DefaultRedaction() Redaction {
+	return Redaction{
+		RedactHeaders: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-HubSpot-Signature", "X-HubSpot-Signature-V3"},
+		RedactFields:  []string{"email", "phone", "fax", "token", "secret", "password"},
+		RedactEmails:  true,
+	}
+}
+
+// emailPattern matches email addresses inside strings
+var emailPattern = regexp.MustCompile(`[^\s@"'<>(),;:/?]+@[^\s@"'<>(),;:/?]+\.[a-zA-Z]{2,}`)
+
+// tokenPathPattern matches the token segment of the OAuth token info paths, e.g. /oauth/v1/access-tokens/{token}
+var tokenPathPattern = regexp.MustCompile(`(/oauth/v1/(?:access|refresh)-tokens/)[^/?#]+`)
+
+// credentialHeaders are redacted by every Redaction, so a zero Redaction never logs or records credentials
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactor applies a Redaction
+type redactor struct {
+	headers map[string]bool
+	fields  []string
+	emails  bool
+}
+
+// newRedactor normalizes the rules of a Redaction
+func newRedactor(cfg Redaction) *redactor {
+	r := &redactor{
+		headers: make(map[string]bool, len(credentialHeaders)+len(cfg.RedactHeaders)),
+		fields:  make([]string, 0, len(cfg.RedactFields)),
+		emails:  cfg.RedactEmails,
+	}
+	for _, header := range slices.Concat(credentialHeaders, cfg.RedactHeaders) {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, field := range cfg.RedactFields {
+		r.fields = append(r.fields, strings.ToLower(field))
+	}
+	return r
+}
+
+// redactBody redacts a JSON body. Bodies that aren't JSON can't be redacted and return an error.
+func (r *redactor) redactBody(body []byte) ([]byte, error) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(r.redactJSON(value))
+}
+
+// redactHeaders returns a copy of headers with the redacted values replaced
+func (r *redactor) redactHeaders(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		if r.headers[http.CanonicalHeaderKey(name)] {
+			redacted[name] = []string{redactedValue}
+			continue
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// redactQuery encodes query parameters with the redacted values replaced
+func (r *redactor) redactQuery(query url.Values) string {
+	redacted := make(url.Values, len(query))
+	for key, values := range query {
+		if r.redactField(key) {
+			redacted[key] = []string{redactedValue}
+			continue
+		}
+		for _, value := range values {
+			redacted.Add(key, r.redactString(value))
+		}
+	}
+	return redacted.Encode()
+}
+
+// redactPath redacts OAuth tokens and email addresses used as IDs in the path. Tokens are always redacted.
+func (r *redactor) redactPath(path string) string {
+	path = tokenPathPattern.ReplaceAllString(path, "${1}"+redactedValue)
+	if !r.emails {
+		return path
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	return emailPattern.ReplaceAllString(path, redactedValue)
+}
+
+// redactJSON redacts the fields and values of a decoded JSON value in place
+func (r *redactor) redactJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		// HubSpot filters and property definitions name the property in one field and hold its value in another
+		if name, ok := v["propertyName"].(string); ok && r.redactField(name) {
+			for _, key := range []string{"value", "values", "highValue"} {
+				if _, ok := v[key]; ok {
+					v[key] = redactedValue
+				}
+			}
+		}
+		for key, field := range v {
+			if r.redactField(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.redactJSON(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactJSON(item)
+		}
+		return v
+	case string:
+		return r.redactString(v)
+	default:
+		return v
+	}
+}
+
+// redactField reports whether the value of a field or query parameter is redacted
+func (r *redactor) redactField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range r.fields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString redacts the email addresses in a string
+func (r *redactor) redactString(s string) string {
+	if !r.emails {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, redactedValue)
+}
@@ -1,6 +1,9 @@
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Request struct {
 	Method      string
@@ -10,11 +13,23 @@ type Request struct {
 	Headers     map[string]string
 
 	// Metadata for middleware
-	ResourceType string
-	RetryCount   int
+	ResourceType   string
+	RateLimitClass string // Overrides the endpoint class derived from Path for rate limit policies
+	RetryCount     int
+	RetryPolicy    RetryPolicy   // Overrides the client's retry policy for this request
+	Timeout        time.Duration // Bounds the whole call when positive
+	SkipRateLimit  bool          // Sends the request without waiting for the rate limiters
+	SkipCache      bool          // Fetches a fresh response instead of a cached one
+	ReadOnly       bool          // Marks a POST that only reads, e.g. a search, so it doesn't invalidate cached responses
+	Priority       Priority      // Scheduling class in the rate limit stage, defaults to PriorityNormal
+	Stream         bool          // Leaves successful response bodies unread in Response.BodyStream
 
 	// Context for timeouts/cancellation
 	Context context.Context
+
+	// Measurements reported to Instrumentation
+	rateLimitWait time.Duration
+	bodySize      int
 }
 
 func NewRequest(method, path string) *Request {
@@ -37,6 +52,21 @@ func (r *Request) WithResourceType(resourceType string) *Request {
 	return r
 }
 
+func (r *Request) WithRateLimitClass(class string) *Request {
+	r.RateLimitClass = class
+	return r
+}
+
+func (r *Request) WithRetryPolicy(policy RetryPolicy) *Request {
+	r.RetryPolicy = policy
+	return r
+}
+
+func (r *Request) WithReadOnly() *Request {
+	r.ReadOnly = true
+	return r
+}
+
 func (r *Request) WithBody(body any) *Request {
 	r.Body = body
 	return r
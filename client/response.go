@@ -1,6 +1,7 @@
 package client
 
 import (
+	"io"
 	"net/http"
 	"time"
 )
@@ -16,6 +17,12 @@ type Response struct {
 
 	// HubSpot error from HubSpot (if applicable)
 	HubSpotError *HubSpotError
+
+	// Cached is true if the response was served from the response cache
+	Cached bool
+
+	// BodyStream is the unread body of a streamed response, in which case Body is nil. The caller must Close the response.
+	BodyStream io.ReadCloser
 }
 
 type RateLimitInfo struct {
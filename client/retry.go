@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides whether a failed attempt is retried and how long to wait before the next one
+type RetryPolicy interface {
+	// Retry is called after every failed attempt, counted from 0. resp is nil if no response was received.
+	Retry(req *Request, resp *Response, err error, attempt int) (delay time.Duration, retry bool)
+}
+
+// RetryPolicyFunc adapts a function to a RetryPolicy
+type RetryPolicyFunc func(req *Request, resp *Response, err error, attempt int) (time.Duration, bool)
+
+// Retry implements RetryPolicy
+func (f RetryPolicyFunc) Retry(req *Request, resp *Response, err error, attempt int) (time.Duration, bool) {
+	return f(req, resp, err, attempt)
+}
+
+// NoRetry is a RetryPolicy that never retries, e.g. for a single request that must not be repeated
+var NoRetry RetryPolicy = RetryPolicyFunc(func(*Request, *Response, error, int) (time.Duration, bool) {
+	return 0, false
+})
+
+// DefaultRetryPolicy retries retryable HubSpot errors for every method, honoring Retry-After,
+// and transient transport errors (connection resets, timeouts, TLS handshake failures) for idempotent methods
+type DefaultRetryPolicy struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// NewDefaultRetryPolicy creates a DefaultRetryPolicy using the backoff from cfg
+func NewDefaultRetryPolicy(cfg RetryConfig) *DefaultRetryPolicy {
+	return &DefaultRetryPolicy{
+		InitialBackoff: cfg.InitialBackoff,
+		MaxBackoff:     cfg.MaxBackoff,
+	}
+}
+
+// Retry implements RetryPolicy
+func (p *DefaultRetryPolicy) Retry(req *Request, resp *Response, err error, attempt int) (time.Duration, bool) {
+	if req.Context != nil && req.Context.Err() != nil {
+		return 0, false
+	}
+
+	cfg := RetryConfig{InitialBackoff: p.InitialBackoff, MaxBackoff: p.MaxBackoff}
+
+	var hubspotErr *HubSpotError
+	if errors.As(err, &hubspotErr) {
+		if !hubspotErr.IsRetryable {
+			return 0, false
+		}
+		return calculateBackoffDuration(attempt, hubspotErr.RetryAfter, cfg), true
+	}
+
+	// The request may have reached HubSpot before the connection failed, so only repeat it if that is safe
+	if resp == nil && IsIdempotent(req.Method) && IsTransientError(err) {
+		return calculateBackoffDuration(attempt, 0, cfg), true
+	}
+
+	return 0, false
+}
+
+// IsIdempotent reports whether requests with the given method can safely be repeated
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransientError reports whether a transport error is likely to succeed on retry:
+// connection resets and refusals, unexpected EOFs, network timeouts and TLS handshake failures.
+// Context cancellation and certificate errors are not transient.
+//
+// Timeouts of a single attempt, e.g. http.Client.Timeout, wrap context.DeadlineExceeded like an expired request
+// context does, so both are transient here. Callers must check the request context to tell them apart, as
+// DefaultRetryPolicy does.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &certErr) || errors.As(err, &unknownAuthorityErr) || errors.As(err, &hostnameErr) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var recordErr tls.RecordHeaderError
+	if errors.As(err, &recordErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlakyServer returns a server that drops the connection for the first failures requests
+func newFlakyServer(t *testing.T, failures int) (*httptest.Server, *int) {
+	t.Helper()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= failures {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_ = conn.Close()
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"success": true}`)
+	}))
+	return server, &attempts
+}
+
+// TestDefaultRetryPolicy tests which failures the default policy retries
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := &DefaultRetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+	get := NewRequest(http.MethodGet, "/test")
+	post := NewRequest(http.MethodPost, "/test")
+
+	tests := []struct {
+		name     string
+		req      *Request
+		resp     *Response
+		err      error
+		expected bool
+	}{
+		{"Retryable HubSpot error", post, &Response{}, &HubSpotError{Status: 503, IsRetryable: true}, true},
+		{"Non-retryable HubSpot error", get, &Response{}, &HubSpotError{Status: 400}, false},
+		{"Connection reset on GET", get, nil, fmt.Errorf("HTTP request failed: %w", syscall.ECONNRESET), true},
+		{"Connection reset on POST", post, nil, fmt.Errorf("HTTP request failed: %w", syscall.ECONNRESET), false},
+		{"Unexpected EOF on DELETE", NewRequest(http.MethodDelete, "/test"), nil, io.ErrUnexpectedEOF, true},
+		{"Network timeout", get, nil, &net.DNSError{IsTimeout: true}, true},
+		{"Context canceled", get, nil, context.Canceled, false},
+		{"Attempt deadline exceeded", get, nil, fmt.Errorf("HTTP request failed: %w", context.DeadlineExceeded), true},
+		{"Other error", get, nil, errors.New("failed to marshal request body"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, retry := policy.Retry(tt.req, tt.resp, tt.err, 0)
+			assert.Equal(t, tt.expected, retry)
+		})
+	}
+
+	t.Run("Request deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+		defer cancel()
+		<-ctx.Done()
+
+		_, retry := policy.Retry(NewRequest(http.MethodGet, "/test").WithContext(ctx), nil, ctx.Err(), 0)
+		assert.False(t, retry)
+	})
+
+	t.Run("Honors Retry-After", func(t *testing.T) {
+		delay, retry := policy.Retry(get, &Response{}, &HubSpotError{Status: 429, IsRetryable: true, RetryAfter: 2 * time.Second}, 0)
+		assert.True(t, retry)
+		assert.Equal(t, 2*time.Second, delay)
+	})
+}
+
+// TestRetryMiddleware_TransportErrors tests retrying dropped connections
+func TestRetryMiddleware_TransportErrors(t *testing.T) {
+	newClient := func(serverURL string, opts ...Option) *Client {
+		c, err := NewClient(append([]Option{
+			WithBaseURL(serverURL),
+			WithRateLimitEnabled(false),
+			WithRetryMaxAttempts(3),
+			WithRetryBackoff(10*time.Millisecond, 100*time.Millisecond),
+		}, opts...)...)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("Idempotent request is retried", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 2)
+		defer server.Close()
+
+		resp, err := newClient(server.URL).Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, *attempts)
+	})
+
+	t.Run("Non-idempotent request is not retried", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 2)
+		defer server.Close()
+
+		_, err := newClient(server.URL).Do(context.Background(), NewRequest(http.MethodPost, "/test"))
+		require.Error(t, err)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("Per-request policy override", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 2)
+		defer server.Close()
+
+		_, err := newClient(server.URL).Do(context.Background(), NewRequest(http.MethodGet, "/test").WithRetryPolicy(NoRetry))
+		require.Error(t, err)
+		assert.Equal(t, 1, *attempts)
+	})
+
+	t.Run("Custom client policy", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 1)
+		defer server.Close()
+
+		var seen []int
+		policy := RetryPolicyFunc(func(req *Request, resp *Response, err error, attempt int) (time.Duration, bool) {
+			seen = append(seen, attempt)
+			return time.Millisecond, true
+		})
+
+		resp, err := newClient(server.URL, WithRetryPolicy(policy)).Do(context.Background(), NewRequest(http.MethodPost, "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, *attempts)
+		assert.Equal(t, []int{0}, seen)
+	})
+
+	t.Run("Max elapsed time", func(t *testing.T) {
+		server, attempts := newFlakyServer(t, 5)
+		defer server.Close()
+
+		c := newClient(server.URL,
+			WithRetryMaxAttempts(5),
+			WithRetryBackoff(50*time.Millisecond, 50*time.Millisecond),
+			WithRetryMaxElapsedTime(75*time.Millisecond),
+		)
+		start := time.Now()
+		_, err := c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		require.Error(t, err)
+		assert.Equal(t, 2, *attempts)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("Client timeout is retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				// Stall past the client timeout
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+				return
+			}
+			respondJSON(w, http.StatusOK, `{"success": true}`)
+		}))
+		defer server.Close()
+
+		resp, err := newClient(server.URL, WithTimeout(50*time.Millisecond)).Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, attempts)
+	})
+}
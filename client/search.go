@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// SearchResultsCeiling is the number of results HubSpot's CRM search pages through before it rejects the after cursor
+const SearchResultsCeiling = 10000
+
+// SearchFunc sends a CRM search whose request body is body and returns a page of results with the after cursor of
+// the next page. body marshals to the JSON of the caller's search input with the paging fields rewritten.
+type SearchFunc[T any] func(ctx context.Context, body any) (Page[T], error)
+
+// PaginateSearch returns an iterator over every result of a CRM search, like Paginate, that pages past HubSpot's
+// 10,000-result ceiling. input is the search input; id returns the hs_object_id of a result.
+//
+// Searches without sorts are sorted by hs_object_id ascending. Before the after cursor reaches the ceiling, the
+// search is re-issued with a GT filter on the last hs_object_id seen added to every filter group, so the rest of
+// the results are paged from the start again. HubSpot caps the number of filters in a search, so the filter groups
+// of input must each leave room for one more filter. Searches with other sorts can't be paged this way and yield an
+// error once they reach the ceiling.
+func PaginateSearch[T any](ctx context.Context, input any, search SearchFunc[T], id func(T) string) iter.Seq2[T, error] {
+	return Paginate(ctx, func(ctx context.Context, cursor string) (Page[T], error) {
+		// The cursor is "<last hs_object_id of the previous windows>/<after cursor within the window>"
+		minID, after, _ := strings.Cut(cursor, "/")
+		if offset, err := strconv.Atoi(after); err == nil && offset >= SearchResultsCeiling {
+			return Page[T]{}, fmt.Errorf("search results past %d can only be paged when sorted by hs_object_id", SearchResultsCeiling)
+		}
+		body := &keysetSearch{input: input, minID: minID, after: after, first: cursor == ""}
+
+		page, err := search(ctx, body)
+		if err != nil || page.Next == "" {
+			return page, err
+		}
+
+		// Re-issue the search after the last ID seen before the next page would cross the ceiling
+		offset, err := strconv.Atoi(page.Next)
+		if err == nil && offset+len(page.Results) > SearchResultsCeiling && len(page.Results) > 0 {
+			keyset, err := body.keyset()
+			if err != nil {
+				return Page[T]{}, err
+			}
+			if keyset {
+				page.Next = id(page.Results[len(page.Results)-1]) + "/"
+				return page, nil
+			}
+		}
+
+		page.Next = minID + "/" + page.Next
+		return page, nil
+	})
+}
+
+// keysetSearch is the request body of a page of PaginateSearch
+type keysetSearch struct {
+	input any
+	minID string // Only results with a greater hs_object_id are searched, "" for the first window
+	after string // The after cursor within the window
+	first bool   // The first page keeps the after cursor of input
+}
+
+func (s *keysetSearch) MarshalJSON() ([]byte, error) {
+	fields, err := searchFields(s.input)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]any, len(fields)+2)
+	for name, value := range fields {
+		body[name] = value
+	}
+	// Pages without a cursor, e.g. the first page of a keyset window, leave out after rather than send it empty
+	switch {
+	case s.first && string(fields["after"]) == `""`:
+		delete(body, "after")
+	case s.first:
+	case s.after == "":
+		delete(body, "after")
+	default:
+		body["after"] = s.after
+	}
+	if len(searchSorts(fields)) == 0 {
+		body["sorts"] = []string{"hs_object_id"}
+	}
+
+	if s.minID != "" {
+		var groups []map[string]any
+		if raw, ok := fields["filterGroups"]; ok {
+			if err := json.Unmarshal(raw, &groups); err != nil {
+				return nil, fmt.Errorf("failed to decode search filter groups: %w", err)
+			}
+		}
+		if len(groups) == 0 {
+			groups = []map[string]any{{}}
+		}
+
+		filter := map[string]string{"propertyName": "hs_object_id", "operator": "GT", "value": s.minID}
+		for _, group := range groups {
+			filters, _ := group["filters"].([]any)
+			group["filters"] = append(filters, filter)
+		}
+		body["filterGroups"] = groups
+	}
+
+	return json.Marshal(body)
+}
+
+// keyset reports whether the search can be paged by hs_object_id, i.e. it has no sorts or is sorted by hs_object_id ascending
+func (s *keysetSearch) keyset() (bool, error) {
+	fields, err := searchFields(s.input)
+	if err != nil {
+		return false, err
+	}
+
+	sorts := searchSorts(fields)
+	switch len(sorts) {
+	case 0:
+		return true, nil
+	case 1:
+		var name string
+		if err := json.Unmarshal(sorts[0], &name); err == nil {
+			return name == "hs_object_id", nil
+		}
+		var sort struct {
+			PropertyName string `json:"propertyName"`
+			Direction    string `json:"direction"`
+		}
+		if err := json.Unmarshal(sorts[0], &sort); err == nil {
+			return sort.PropertyName == "hs_object_id" && sort.Direction != "DESCENDING", nil
+		}
+	}
+	return false, nil
+}
+
+// searchFields returns the top-level fields of a search input
+func searchFields(input any) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search input: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("search input must be a JSON object: %w", err)
+	}
+	return fields, nil
+}
+
+// searchSorts returns the sorts of a search input, which are strings or objects
+func searchSorts(fields map[string]json.RawMessage) []json.RawMessage {
+	var sorts []json.RawMessage
+	if raw, ok := fields["sorts"]; ok {
+		_ = json.Unmarshal(raw, &sorts)
+	}
+	return sorts
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaginateSearch tests paging CRM searches past the 10,000-result ceiling
+func TestPaginateSearch(t *testing.T) {
+	type searchInput struct {
+		After        string           `json:"after"`
+		Sorts        []string         `json:"sorts"`
+		FilterGroups []map[string]any `json:"filterGroups"`
+	}
+	type searchBody struct {
+		After        *string `json:"after"`
+		Sorts        []any   `json:"sorts"`
+		FilterGroups []struct {
+			Filters []struct {
+				PropertyName string `json:"propertyName"`
+				Operator     string `json:"operator"`
+				Value        string `json:"value"`
+			} `json:"filters"`
+		} `json:"filterGroups"`
+	}
+
+	// search serves the IDs 1 to total in pages of 100, honoring GT filters on hs_object_id like HubSpot
+	search := func(t *testing.T, total int, bodies *[]searchBody) SearchFunc[int] {
+		return func(ctx context.Context, body any) (Page[int], error) {
+			raw, err := json.Marshal(body)
+			require.NoError(t, err)
+			var decoded searchBody
+			require.NoError(t, json.Unmarshal(raw, &decoded))
+			*bodies = append(*bodies, decoded)
+
+			minID := 0
+			for _, group := range decoded.FilterGroups {
+				for _, filter := range group.Filters {
+					if filter.PropertyName == "hs_object_id" && filter.Operator == "GT" {
+						minID, _ = strconv.Atoi(filter.Value)
+					}
+				}
+			}
+			offset := 0
+			if decoded.After != nil {
+				require.NotEmpty(t, *decoded.After, "sent an empty after cursor")
+				offset, _ = strconv.Atoi(*decoded.After)
+			}
+			require.Less(t, offset, SearchResultsCeiling, "paged past the ceiling")
+
+			var page Page[int]
+			for id := minID + offset + 1; id <= total && len(page.Results) < 100; id++ {
+				page.Results = append(page.Results, id)
+			}
+			if minID+offset+len(page.Results) < total {
+				page.Next = strconv.Itoa(offset + len(page.Results))
+			}
+			return page, nil
+		}
+	}
+	id := func(result int) string { return strconv.Itoa(result) }
+
+	t.Run("Switches to keyset pagination at the ceiling", func(t *testing.T) {
+		var bodies []searchBody
+		input := &searchInput{FilterGroups: []map[string]any{{"filters": []map[string]string{{"propertyName": "email", "operator": "HAS_PROPERTY"}}}}}
+
+		count, last := 0, 0
+		for result, err := range PaginateSearch(context.Background(), input, search(t, 10250, &bodies), id) {
+			require.NoError(t, err)
+			require.Greater(t, result, last)
+			count++
+			last = result
+		}
+		assert.Equal(t, 10250, count)
+
+		assert.Equal(t, []any{"hs_object_id"}, bodies[0].Sorts)
+		require.Len(t, bodies, 103)
+		keyset := bodies[100]
+		assert.Nil(t, bodies[0].After)
+		assert.Nil(t, keyset.After)
+		require.Len(t, keyset.FilterGroups, 1)
+		require.Len(t, keyset.FilterGroups[0].Filters, 2)
+		assert.Equal(t, "email", keyset.FilterGroups[0].Filters[0].PropertyName)
+		assert.Equal(t, "10000", keyset.FilterGroups[0].Filters[1].Value)
+		assert.Nil(t, input.Sorts)
+	})
+
+	t.Run("Small result sets page normally", func(t *testing.T) {
+		var bodies []searchBody
+		var results []int
+		for result, err := range PaginateSearch(context.Background(), &searchInput{}, search(t, 150, &bodies), id) {
+			require.NoError(t, err)
+			results = append(results, result)
+		}
+		assert.Len(t, results, 150)
+		require.Len(t, bodies, 2)
+		require.NotNil(t, bodies[1].After)
+		assert.Equal(t, "100", *bodies[1].After)
+		assert.Empty(t, bodies[1].FilterGroups)
+	})
+
+	t.Run("Custom sorts stop at the ceiling", func(t *testing.T) {
+		var bodies []searchBody
+		input := &searchInput{Sorts: []string{"-createdate"}}
+
+		count := 0
+		var iterErr error
+		for _, err := range PaginateSearch(context.Background(), input, search(t, 10250, &bodies), id) {
+			if err != nil {
+				iterErr = err
+				continue
+			}
+			count++
+		}
+		assert.ErrorContains(t, iterErr, "sorted by hs_object_id")
+		assert.Equal(t, SearchResultsCeiling, count)
+		assert.Equal(t, []any{"-createdate"}, bodies[0].Sorts)
+	})
+}
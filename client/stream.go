@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Reader returns the response body, reading from the stream for streamed responses
+func (r *Response) Reader() io.Reader {
+	if r.BodyStream != nil {
+		return r.BodyStream
+	}
+	return bytes.NewReader(r.Body)
+}
+
+// Decoder returns a JSON decoder reading the response body
+func (r *Response) Decoder() *json.Decoder {
+	return json.NewDecoder(r.Reader())
+}
+
+// Close closes the body of a streamed response. It is a no-op for buffered responses.
+func (r *Response) Close() error {
+	if r.BodyStream == nil {
+		return nil
+	}
+	return r.BodyStream.Close()
+}
+
+// DecodeResults decodes a HubSpot page from r, passing each element of its "results" array to fn as it is read
+// instead of holding the whole page in memory. The other top-level fields, e.g. "paging" and "total", are returned
+// undecoded. Decoding stops at the first error returned by fn.
+func DecodeResults[T any](r io.Reader, fn func(T) error) (map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read field name: %w", err)
+		}
+		name, _ := token.(string)
+
+		if name != "results" {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to decode field %s: %w", name, err)
+			}
+			fields[name] = raw
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return nil, err
+		}
+		for dec.More() {
+			var result T
+			if err := dec.Decode(&result); err != nil {
+				return nil, fmt.Errorf("failed to decode result: %w", err)
+			}
+			if err := fn(result); err != nil {
+				return nil, err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// DecodeField unmarshals a field returned by DecodeResults into v, leaving v untouched if the field is missing
+func DecodeField(fields map[string]json.RawMessage, name string, v any) error {
+	raw, ok := fields[name]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to decode field %s: %w", name, err)
+	}
+	return nil
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q in response body, got %v", delim, token)
+	}
+	return nil
+}
+
+// responseBodyReader returns the HTTP response body, decompressing it if it is gzip encoded
+func responseBodyReader(httpResp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(httpResp.Header.Get("Content-Encoding"), "gzip") {
+		return httpResp.Body, nil
+	}
+
+	zr, err := gzip.NewReader(httpResp.Body)
+	if errors.Is(err, io.EOF) {
+		// Empty body, e.g. for a 204
+		return httpResp.Body, nil
+	}
+	if err != nil {
+		_ = httpResp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response body: %w", err)
+	}
+	return &gzipBody{Reader: zr, body: httpResp.Body}, nil
+}
+
+// gzipBody closes both the gzip reader and the underlying body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
+// cancelOnClose cancels the context of a streamed call once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
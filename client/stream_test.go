@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respondGzip writes a gzip encoded JSON response
+func respondGzip(t *testing.T, w http.ResponseWriter, statusCode int, body string) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// TestDecodeResults tests token-level decoding of results arrays
+func TestDecodeResults(t *testing.T) {
+	type record struct {
+		ID string `json:"id"`
+	}
+	page := `{"total": 3, "results": [{"id": "1"}, {"id": "2"}, {"id": "3"}], "paging": {"next": {"after": "3"}}}`
+
+	t.Run("Passes each result and returns the other fields", func(t *testing.T) {
+		var ids []string
+		fields, err := DecodeResults(strings.NewReader(page), func(r record) error {
+			ids = append(ids, r.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+		var total int
+		require.NoError(t, DecodeField(fields, "total", &total))
+		assert.Equal(t, 3, total)
+		assert.JSONEq(t, `{"next": {"after": "3"}}`, string(fields["paging"]))
+	})
+
+	t.Run("Stops at the first callback error", func(t *testing.T) {
+		stop := errors.New("stop")
+		count := 0
+		_, err := DecodeResults(strings.NewReader(page), func(r record) error {
+			count++
+			if r.ID == "2" {
+				return stop
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Missing results", func(t *testing.T) {
+		fields, err := DecodeResults(strings.NewReader(`{"paging": {}}`), func(r record) error {
+			t.Fatal("unexpected result")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Contains(t, fields, "paging")
+	})
+
+	t.Run("Malformed body", func(t *testing.T) {
+		_, err := DecodeResults(strings.NewReader(`{"results": [{"id": "1"},`), func(r record) error { return nil })
+		assert.Error(t, err)
+
+		_, err = DecodeResults(strings.NewReader(`[]`), func(r record) error { return nil })
+		assert.Error(t, err)
+	})
+}
+
+// TestGzipResponses tests requesting and decoding gzip encoded responses
+func TestGzipResponses(t *testing.T) {
+	t.Run("Decodes gzip bodies", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+			respondGzip(t, w, http.StatusOK, `{"id": "1"}`)
+		})
+		defer server.Close()
+
+		resp, err := c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "1"}`, string(resp.Body))
+	})
+
+	t.Run("Decodes gzip error bodies", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondGzip(t, w, http.StatusBadRequest, `{"status": "error", "message": "Invalid input", "category": "VALIDATION_ERROR"}`)
+		})
+		defer server.Close()
+
+		_, err := c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		var hubspotErr *HubSpotError
+		require.ErrorAs(t, err, &hubspotErr)
+		assert.Equal(t, "Invalid input", hubspotErr.Message)
+	})
+
+	t.Run("Compression disabled", func(t *testing.T) {
+		server, _ := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get("Accept-Encoding"))
+			respondJSON(w, http.StatusOK, `{}`)
+		})
+		defer server.Close()
+
+		// Disable the transport's own transparent compression so the header is observable
+		c, err := NewClient(WithBaseURL(server.URL), WithCompression(false), WithRateLimitEnabled(false),
+			WithTransport(&http.Transport{DisableCompression: true}))
+		require.NoError(t, err)
+
+		_, err = c.Do(context.Background(), NewRequest(http.MethodGet, "/test"))
+		require.NoError(t, err)
+	})
+}
+
+// TestStreamingResponses tests leaving response bodies unread
+func TestStreamingResponses(t *testing.T) {
+	streamed := func(opts ...CallOption) *Request {
+		req := NewRequest(http.MethodGet, "/test")
+		WithStreaming()(req)
+		for _, opt := range opts {
+			opt(req)
+		}
+		return req
+	}
+
+	t.Run("Body is streamed", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondGzip(t, w, http.StatusOK, `{"results": [{"id": "1"}]}`)
+		})
+		defer server.Close()
+
+		resp, err := c.Do(context.Background(), streamed())
+		require.NoError(t, err)
+		defer resp.Close()
+
+		assert.Nil(t, resp.Body)
+		require.NotNil(t, resp.BodyStream)
+		body, err := io.ReadAll(resp.Reader())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"results": [{"id": "1"}]}`, string(body))
+	})
+
+	t.Run("Error bodies are read", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusNotFound, `{"status": "error", "message": "Not found"}`)
+		})
+		defer server.Close()
+
+		resp, err := c.Do(context.Background(), streamed())
+		require.Error(t, err)
+		assert.Nil(t, resp.BodyStream)
+		assert.NotEmpty(t, resp.Body)
+	})
+
+	t.Run("Call timeout outlives Do until the body is closed", func(t *testing.T) {
+		server, c := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			respondJSON(w, http.StatusOK, `{"results": []}`)
+		})
+		defer server.Close()
+
+		resp, err := c.Do(context.Background(), streamed(WithCallTimeout(time.Second)))
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, resp.Decoder().Decode(&decoded))
+		require.NoError(t, resp.Close())
+	})
+}
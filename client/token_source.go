@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenURL is HubSpot's OAuth token endpoint
+const DefaultTokenURL = "https://api.hubapi.com/oauth/v1/token"
+
+// Token is an OAuth access token along with the refresh token used to renew it
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time // Zero if the token never expires
+}
+
+// validFor reports whether the token has an access token that will still be valid after d
+func (t Token) validFor(d time.Duration) bool {
+	if t.AccessToken == "" {
+		return false
+	}
+	return t.ExpiresAt.IsZero() || time.Now().Add(d).Before(t.ExpiresAt)
+}
+
+// TokenSource supplies the access token used by the auth middleware
+//
+// Implementations must be safe for concurrent use
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenInvalidator is implemented by TokenSources that can discard an access token rejected by HubSpot.
+// The auth middleware uses it to refresh and retry once on a 401.
+type TokenInvalidator interface {
+	// Invalidate discards accessToken if it is still the current token and reports whether it did,
+	// in which case the next call to Token obtains a new token
+	Invalidate(accessToken string) bool
+}
+
+// staticTokenSource always returns the same access token
+type staticTokenSource struct {
+	token Token
+}
+
+// StaticTokenSource returns a TokenSource that always returns the given access token
+func StaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: Token{AccessToken: accessToken}}
+}
+
+// Token implements TokenSource
+func (s *staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	token := s.token
+	return &token, nil
+}
+
+// OAuthConfig configures an OAuthTokenSource
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string // Only sent if set
+
+	// TokenURL defaults to DefaultTokenURL
+	TokenURL string
+
+	// HTTPClient is used for refresh requests, defaults to a client with a 30 second timeout
+	HTTPClient *http.Client
+
+	// RefreshBuffer is how long before expiry the access token is proactively refreshed, defaults to 5 minutes
+	RefreshBuffer time.Duration
+
+	// OnRefresh is called after every successful refresh with the newly issued tokens so they can be persisted
+	OnRefresh func(token Token)
+}
+
+// OAuthTokenSource is a TokenSource that renews access tokens with HubSpot's refresh_token grant
+//
+// Only one refresh is in flight at a time; concurrent callers wait for its result.
+type OAuthTokenSource struct {
+	config OAuthConfig
+
+	mu       sync.Mutex
+	token    Token
+	inflight *refreshCall
+}
+
+// refreshCall is a refresh request shared by every caller waiting on it
+type refreshCall struct {
+	done  chan struct{}
+	token Token
+	err   error
+}
+
+// oauthTokenResponse is the body returned by the token endpoint
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+// NewOAuthTokenSource creates a TokenSource seeded with the given token.
+// The access token and expiry may be empty, in which case the first call to Token refreshes.
+func NewOAuthTokenSource(cfg OAuthConfig, initial Token) (*OAuthTokenSource, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("oauth client ID and client secret are required")
+	}
+	if initial.RefreshToken == "" {
+		return nil, fmt.Errorf("oauth refresh token is required")
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = DefaultTokenURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.RefreshBuffer <= 0 {
+		cfg.RefreshBuffer = 5 * time.Minute
+	}
+
+	return &OAuthTokenSource{
+		config: cfg,
+		token:  initial,
+	}, nil
+}
+
+// Token returns the current access token, refreshing it first if it expires within the refresh buffer
+func (s *OAuthTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	if s.token.validFor(s.config.RefreshBuffer) {
+		token := s.token
+		s.mu.Unlock()
+		return &token, nil
+	}
+	call := s.startRefreshLocked(ctx)
+	s.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		token := call.token
+		return &token, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate implements TokenInvalidator
+func (s *OAuthTokenSource) Invalidate(accessToken string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if accessToken == "" || s.token.AccessToken != accessToken {
+		return false
+	}
+	s.token.AccessToken = ""
+	return true
+}
+
+// startRefreshLocked returns the in-flight refresh, starting one if needed. s.mu must be held.
+func (s *OAuthTokenSource) startRefreshLocked(ctx context.Context) *refreshCall {
+	if s.inflight != nil {
+		return s.inflight
+	}
+
+	call := &refreshCall{done: make(chan struct{})}
+	s.inflight = call
+	refreshToken := s.token.RefreshToken
+
+	// The refresh outlives the caller that triggered it so other waiters still get a result
+	go func() {
+		token, err := s.refresh(context.WithoutCancel(ctx), refreshToken)
+
+		// Persist before the token is used so a rotated refresh token is never used unsaved.
+		// Callers arriving meanwhile join the refresh still in flight.
+		if err == nil && s.config.OnRefresh != nil {
+			s.config.OnRefresh(token)
+		}
+
+		s.mu.Lock()
+		if err == nil {
+			s.token = token
+		}
+		s.inflight = nil
+		s.mu.Unlock()
+
+		call.token, call.err = token, err
+		close(call.done)
+	}()
+
+	return call
+}
+
+// refresh exchanges the refresh token for a new access token
+func (s *OAuthTokenSource) refresh(ctx context.Context, refreshToken string) (Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", s.config.ClientID)
+	form.Set("client_secret", s.config.ClientSecret)
+	form.Set("refresh_token", refreshToken)
+	if s.config.RedirectURI != "" {
+		form.Set("redirect_uri", s.config.RedirectURI)
+	}
+
+	tokenResp, err := requestToken(ctx, s.config.HTTPClient, s.config.TokenURL, form)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to refresh oauth token: %w", err)
+	}
+
+	token := tokenResp.token()
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// token converts the token endpoint response into a Token
+func (r *oauthTokenResponse) token() Token {
+	token := Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+	}
+	if r.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return token
+}
+
+// RequestToken posts a form to an OAuth token endpoint and returns the issued token.
+// It is used for grants other than refresh_token, e.g. the authorization_code exchange.
+// Errors returned by the endpoint are returned as *HubSpotError.
+func RequestToken(ctx context.Context, httpClient *http.Client, tokenURL string, form url.Values) (*Token, error) {
+	tokenResp, err := requestToken(ctx, httpClient, tokenURL, form)
+	if err != nil {
+		return nil, err
+	}
+	token := tokenResp.token()
+	return &token, nil
+}
+
+// requestToken posts a form to the token endpoint
+func requestToken(ctx context.Context, httpClient *http.Client, tokenURL string, form url.Values) (*oauthTokenResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("User-Agent", "go-hubspot-sdk/1.0")
+
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+
+	body, err := readResponseBody(httpResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if httpResp.StatusCode >= 400 {
+		return nil, ParseHubSpotError(httpResp.StatusCode, body, httpResp.Header)
+	}
+
+	var tokenResp oauthTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not contain an access token")
+	}
+
+	return &tokenResp, nil
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTokenServer creates a token endpoint that issues "access-N" tokens for every refresh
+func setupTokenServer(t *testing.T, refreshes *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+
+		if r.PostForm.Get("refresh_token") == "revoked" {
+			respondJSON(w, http.StatusBadRequest, `{"status": "BAD_REFRESH_TOKEN", "message": "missing or unknown refresh token"}`)
+			return
+		}
+
+		n := refreshes.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		respondJSON(w, http.StatusOK, fmt.Sprintf(`{"access_token": "access-%d", "refresh_token": "refresh-%d", "expires_in": 1800, "token_type": "bearer"}`, n, n))
+	}))
+}
+
+func newTestOAuthTokenSource(t *testing.T, tokenURL string, initial Token, onRefresh func(Token)) *OAuthTokenSource {
+	source, err := NewOAuthTokenSource(OAuthConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		TokenURL:     tokenURL,
+		OnRefresh:    onRefresh,
+	}, initial)
+	require.NoError(t, err)
+	return source
+}
+
+// TestOAuthTokenSource tests token refresh behavior
+func TestOAuthTokenSource(t *testing.T) {
+	t.Run("Valid token is not refreshed", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := setupTokenServer(t, &refreshes)
+		defer server.Close()
+
+		source := newTestOAuthTokenSource(t, server.URL, Token{
+			AccessToken:  "current",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "current", token.AccessToken)
+		assert.Equal(t, int32(0), refreshes.Load())
+	})
+
+	t.Run("Refreshes proactively before expiry", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := setupTokenServer(t, &refreshes)
+		defer server.Close()
+
+		var persisted Token
+		source := newTestOAuthTokenSource(t, server.URL, Token{
+			AccessToken:  "current",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Minute),
+		}, func(token Token) { persisted = token })
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access-1", token.AccessToken)
+		assert.Equal(t, "refresh-1", token.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), token.ExpiresAt, 5*time.Second)
+		assert.Equal(t, "refresh-1", persisted.RefreshToken)
+	})
+
+	t.Run("OnRefresh runs before the token is used", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := setupTokenServer(t, &refreshes)
+		defer server.Close()
+
+		var source *OAuthTokenSource
+		var current string
+		source = newTestOAuthTokenSource(t, server.URL, Token{AccessToken: "current", RefreshToken: "refresh", ExpiresAt: time.Now()}, func(Token) {
+			source.mu.Lock()
+			current = source.token.AccessToken
+			source.mu.Unlock()
+		})
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access-1", token.AccessToken)
+		assert.Equal(t, "current", current)
+	})
+
+	t.Run("Invalidate reports whether it cleared the token", func(t *testing.T) {
+		source := newTestOAuthTokenSource(t, "http://localhost", Token{
+			AccessToken:  "current",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		assert.False(t, source.Invalidate("stale"))
+		assert.True(t, source.Invalidate("current"))
+		assert.False(t, source.Invalidate("current"))
+	})
+
+	t.Run("Single refresh in flight", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := setupTokenServer(t, &refreshes)
+		defer server.Close()
+
+		source := newTestOAuthTokenSource(t, server.URL, Token{RefreshToken: "refresh"}, nil)
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := source.Token(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, "access-1", token.AccessToken)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), refreshes.Load())
+	})
+
+	t.Run("Refresh error", func(t *testing.T) {
+		var refreshes atomic.Int32
+		server := setupTokenServer(t, &refreshes)
+		defer server.Close()
+
+		source := newTestOAuthTokenSource(t, server.URL, Token{RefreshToken: "revoked"}, nil)
+
+		_, err := source.Token(context.Background())
+		require.Error(t, err)
+		var hubspotErr *HubSpotError
+		require.ErrorAs(t, err, &hubspotErr)
+		assert.Equal(t, http.StatusBadRequest, hubspotErr.Status)
+	})
+
+	t.Run("Missing credentials", func(t *testing.T) {
+		_, err := NewOAuthTokenSource(OAuthConfig{ClientID: "client-id"}, Token{RefreshToken: "refresh"})
+		require.Error(t, err)
+
+		_, err = NewOAuthTokenSource(OAuthConfig{ClientID: "client-id", ClientSecret: "client-secret"}, Token{})
+		require.Error(t, err)
+	})
+}
+
+// TestAuthMiddleware_TokenSource tests the auth middleware with a refreshing token source
+func TestAuthMiddleware_TokenSource(t *testing.T) {
+	t.Run("Retries once on 401 with a refreshed token", func(t *testing.T) {
+		var refreshes atomic.Int32
+		tokenServer := setupTokenServer(t, &refreshes)
+		defer tokenServer.Close()
+
+		var authHeaders []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+			if r.Header.Get("Authorization") == "Bearer stale" {
+				respondJSON(w, http.StatusUnauthorized, `{"status": "error", "message": "expired"}`)
+				return
+			}
+			respondJSON(w, http.StatusOK, `{}`)
+		}))
+		defer server.Close()
+
+		source := newTestOAuthTokenSource(t, tokenServer.URL, Token{
+			AccessToken:  "stale",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}, nil)
+
+		client, err := NewClient(
+			WithBaseURL(server.URL),
+			WithTokenSource(source),
+			WithRateLimitEnabled(false),
+			WithRetryEnabled(false),
+		)
+		require.NoError(t, err)
+
+		resp, err := client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"Bearer stale", "Bearer access-1"}, authHeaders)
+	})
+
+	t.Run("Static token is not retried on 401", func(t *testing.T) {
+		attempts := 0
+		server, client := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			respondJSON(w, http.StatusUnauthorized, `{"status": "error", "message": "expired"}`)
+		})
+		defer server.Close()
+
+		_, err := client.Do(context.Background(), NewRequest("GET", "/test"))
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
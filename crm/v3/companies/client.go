@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -22,12 +24,16 @@ func NewClient(apiClient *client.Client) *Client {
 }
 
 // CreateCompany creates a new company
-func (c *Client) CreateCompany(ctx context.Context, input *CreateCompanyInput) (*Company, error) {
+func (c *Client) CreateCompany(ctx context.Context, input *CreateCompanyInput, opts ...client.CallOption) (*Company, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -65,12 +71,16 @@ func (c *Client) GetCompany(ctx context.Context, companyID string, opts ...Compa
 }
 
 // UpdateCompany updates a company
-func (c *Client) UpdateCompany(ctx context.Context, companyID string, input *UpdateCompanyInput) (*Company, error) {
+func (c *Client) UpdateCompany(ctx context.Context, companyID string, input *UpdateCompanyInput, opts ...client.CallOption) (*Company, error) {
 	req := client.NewRequest("PATCH", fmt.Sprintf("/crm/v3/objects/companies/%s", companyID))
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -84,12 +94,36 @@ func (c *Client) UpdateCompany(ctx context.Context, companyID string, input *Upd
 	return &company, nil
 }
 
+// CreateOrGetCompany creates a company, or gets the existing company if the create conflicts with one on a unique
+// property value. created reports whether the company was created. opts are applied to both requests.
+func (c *Client) CreateOrGetCompany(ctx context.Context, input *CreateCompanyInput, opts ...client.CallOption) (company *Company, created bool, err error) {
+	return client.CreateOrResolve(func() (*Company, error) {
+		return c.CreateCompany(ctx, input, opts...)
+	}, func(existingID string) (*Company, error) {
+		return c.GetCompany(ctx, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateCompany creates a company, or updates the properties of the existing company if the create conflicts
+// with one on a unique property value. created reports whether the company was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateCompany(ctx context.Context, input *CreateCompanyInput, opts ...client.CallOption) (company *Company, created bool, err error) {
+	return client.CreateOrResolve(func() (*Company, error) {
+		return c.CreateCompany(ctx, input, opts...)
+	}, func(existingID string) (*Company, error) {
+		return c.UpdateCompany(ctx, existingID, &UpdateCompanyInput{Properties: input.Properties}, opts...)
+	})
+}
+
 // ArchiveCompany archives (deletes) a company
-func (c *Client) ArchiveCompany(ctx context.Context, companyID string) error {
+func (c *Client) ArchiveCompany(ctx context.Context, companyID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/companies/%s", companyID))
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
@@ -118,12 +152,17 @@ func (c *Client) ListCompanies(ctx context.Context, opts ...CompanyOption) (*Lis
 }
 
 // BatchReadCompanies retrieves multiple companies by ID
-func (c *Client) BatchReadCompanies(ctx context.Context, input *BatchReadCompaniesInput) (*BatchCompaniesResponse, error) {
+func (c *Client) BatchReadCompanies(ctx context.Context, input *BatchReadCompaniesInput, opts ...client.CallOption) (*BatchCompaniesResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies/batch/read")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
+	req.WithReadOnly()
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -138,12 +177,16 @@ func (c *Client) BatchReadCompanies(ctx context.Context, input *BatchReadCompani
 }
 
 // BatchCreateCompanies creates multiple companies
-func (c *Client) BatchCreateCompanies(ctx context.Context, input *BatchCreateCompaniesInput) (*BatchCompaniesResponse, error) {
+func (c *Client) BatchCreateCompanies(ctx context.Context, input *BatchCreateCompaniesInput, opts ...client.CallOption) (*BatchCompaniesResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies/batch/create")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -158,12 +201,16 @@ func (c *Client) BatchCreateCompanies(ctx context.Context, input *BatchCreateCom
 }
 
 // BatchUpdateCompanies updates multiple companies
-func (c *Client) BatchUpdateCompanies(ctx context.Context, input *BatchUpdateCompaniesInput) (*BatchCompaniesResponse, error) {
+func (c *Client) BatchUpdateCompanies(ctx context.Context, input *BatchUpdateCompaniesInput, opts ...client.CallOption) (*BatchCompaniesResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies/batch/update")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -178,22 +225,36 @@ func (c *Client) BatchUpdateCompanies(ctx context.Context, input *BatchUpdateCom
 }
 
 // BatchArchiveCompanies archives multiple companies
-func (c *Client) BatchArchiveCompanies(ctx context.Context, input *BatchArchiveCompaniesInput) error {
+func (c *Client) BatchArchiveCompanies(ctx context.Context, input *BatchArchiveCompaniesInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies/batch/archive")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
 
 // SearchCompanies searches for companies
-func (c *Client) SearchCompanies(ctx context.Context, input *SearchCompaniesInput) (*SearchCompaniesResponse, error) {
+func (c *Client) SearchCompanies(ctx context.Context, input *SearchCompaniesInput, opts ...client.CallOption) (*SearchCompaniesResponse, error) {
+	return c.searchCompanies(ctx, input, opts...)
+}
+
+// searchCompanies sends a search whose request body is body, which is the search input
+func (c *Client) searchCompanies(ctx context.Context, body any, opts ...client.CallOption) (*SearchCompaniesResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/companies/search")
 	req.WithContext(ctx)
 	req.WithResourceType("companies")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -207,3 +268,36 @@ func (c *Client) SearchCompanies(ctx context.Context, input *SearchCompaniesInpu
 
 	return &searchResp, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every company, fetching the next page once a page has been consumed
+//
+// opts: same as ListCompanies. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, opts ...CompanyOption) iter.Seq2[Company, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Company], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		listResp, err := c.ListCompanies(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[Company]{}, err
+		}
+		return client.Page[Company]{Results: listResp.Results, Next: listResp.Paging.NextAfter()}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *SearchCompaniesInput, opts ...client.CallOption) iter.Seq2[Company, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Company], error) {
+		searchResp, err := c.searchCompanies(ctx, body, opts...)
+		if err != nil {
+			return client.Page[Company]{}, err
+		}
+		return client.Page[Company]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(company Company) string { return company.ID })
+}
@@ -616,3 +616,23 @@ func TestInvalidJSON(t *testing.T) {
 		assert.Contains(t, err.Error(), "unmarshal")
 	})
 }
+
+// TestAll tests that All pages with the after cursor of paging.next
+func TestAll(t *testing.T) {
+	server, companiesClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if after := r.URL.Query().Get("after"); after != "" {
+			assert.Equal(t, "1", after)
+			respondJSON(w, http.StatusOK, `{"results": [{"id": "2", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": [{"id": "1", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}], "paging": {"next": {"after": "1"}}}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for company, err := range companiesClient.All(context.Background()) {
+		require.NoError(t, err)
+		ids = append(ids, company.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
@@ -1,5 +1,7 @@
 package companies
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 // Company represents a HubSpot company object
 type Company struct {
 	ID                    string                           `json:"id"`
@@ -32,22 +34,13 @@ type UpdateCompanyInput struct {
 }
 
 // ListCompaniesResponse represents the response from listing companies
-type ListCompaniesResponse struct {
-	Results []Company `json:"results"`
-	Paging  *Paging   `json:"paging"`
-}
+type ListCompaniesResponse = client.CollectionResponse[Company]
 
 // Paging represents pagination information
-type Paging struct {
-	Next *PagingLink `json:"next"`
-	Prev *PagingLink `json:"prev"`
-}
+type Paging = client.Paging
 
 // PagingLink represents a pagination link
-type PagingLink struct {
-	After string `json:"after"`
-	Link  string `json:"link"`
-}
+type PagingLink = client.PagingLink
 
 // BatchReadCompaniesInput represents input for batch read
 type BatchReadCompaniesInput struct {
@@ -110,8 +103,4 @@ type Filter struct {
 }
 
 // SearchCompaniesResponse represents response from search
-type SearchCompaniesResponse struct {
-	Total   int       `json:"total"`
-	Results []Company `json:"results"`
-	Paging  *Paging   `json:"paging"`
-}
+type SearchCompaniesResponse = client.CollectionResponse[Company]
@@ -8,7 +8,7 @@ import (
 )
 
 // CompanyOption represents a functional option for company requests
-type CompanyOption func(*client.Request)
+type CompanyOption = client.CallOption
 
 // WithProperties specifies which properties to return
 func WithProperties(properties []string) CompanyOption {
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -47,12 +49,16 @@ func (c *Client) GetContact(ctx context.Context, contactID string, opts ...GetCo
 	}, nil
 }
 
-func (c *Client) CreateContact(ctx context.Context, input *CreateContactInput) (*Contact, error) {
+func (c *Client) CreateContact(ctx context.Context, input *CreateContactInput, opts ...client.CallOption) (*Contact, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/contacts")
 	req.WithContext(ctx)
 	req.WithResourceType("contacts")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseContactError(err, "")
@@ -70,12 +76,16 @@ func (c *Client) CreateContact(ctx context.Context, input *CreateContactInput) (
 	}, nil
 }
 
-func (c *Client) UpdateContact(ctx context.Context, contactID string, input *UpdateContactInput) (*Contact, error) {
+func (c *Client) UpdateContact(ctx context.Context, contactID string, input *UpdateContactInput, opts ...client.CallOption) (*Contact, error) {
 	req := client.NewRequest("PATCH", fmt.Sprintf("/crm/v3/objects/contacts/%s", contactID))
 	req.WithContext(ctx)
 	req.WithResourceType("contacts")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseContactError(err, contactID)
@@ -93,11 +103,35 @@ func (c *Client) UpdateContact(ctx context.Context, contactID string, input *Upd
 	}, nil
 }
 
-func (c *Client) DeleteContact(ctx context.Context, contactID string) error {
+// CreateOrGetContact creates a contact, or gets the existing contact if the create conflicts with one on a unique
+// property value. created reports whether the contact was created. opts are applied to both requests.
+func (c *Client) CreateOrGetContact(ctx context.Context, input *CreateContactInput, opts ...client.CallOption) (contact *Contact, created bool, err error) {
+	return client.CreateOrResolve(func() (*Contact, error) {
+		return c.CreateContact(ctx, input, opts...)
+	}, func(existingID string) (*Contact, error) {
+		return c.GetContact(ctx, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateContact creates a contact, or updates the properties of the existing contact if the create conflicts
+// with one on a unique property value. created reports whether the contact was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateContact(ctx context.Context, input *CreateContactInput, opts ...client.CallOption) (contact *Contact, created bool, err error) {
+	return client.CreateOrResolve(func() (*Contact, error) {
+		return c.CreateContact(ctx, input, opts...)
+	}, func(existingID string) (*Contact, error) {
+		return c.UpdateContact(ctx, existingID, &UpdateContactInput{Properties: input.Properties}, opts...)
+	})
+}
+
+func (c *Client) DeleteContact(ctx context.Context, contactID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/contacts/%s", contactID))
 	req.WithContext(ctx)
 	req.WithResourceType("contacts")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseContactError(err, contactID)
@@ -121,16 +155,7 @@ func (c *Client) ListContacts(ctx context.Context, opts ...ListContactsOption) (
 		return nil, "", err
 	}
 
-	var listResp struct {
-		Results []Contact `json:"results"`
-		Paging  struct {
-			Next struct {
-				After string `json:"after"`
-				Link  string `json:"link"`
-			} `json:"next"`
-		} `json:"paging"`
-	}
-
+	var listResp ListContactsResponse
 	if err := json.Unmarshal(resp.Body, &listResp); err != nil {
 		return nil, "", fmt.Errorf("failed to unmarshal contacts list response: %w", err)
 	}
@@ -138,11 +163,21 @@ func (c *Client) ListContacts(ctx context.Context, opts ...ListContactsOption) (
 	return listResp.Results, listResp.Paging.Next.After, nil
 }
 
-func (c *Client) SearchContacts(ctx context.Context, input *SearchContactsInput) (*SearchContactsResponse, error) {
+func (c *Client) SearchContacts(ctx context.Context, input *SearchContactsInput, opts ...client.CallOption) (*SearchContactsResponse, error) {
+	return c.searchContacts(ctx, input, opts...)
+}
+
+// searchContacts sends a search whose request body is body, which is the search input
+func (c *Client) searchContacts(ctx context.Context, body any, opts ...client.CallOption) (*SearchContactsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/contacts/search")
 	req.WithContext(ctx)
 	req.WithResourceType("contacts")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -156,3 +191,36 @@ func (c *Client) SearchContacts(ctx context.Context, input *SearchContactsInput)
 
 	return &searchResp, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every contact, fetching the next page once a page has been consumed
+//
+// opts: same as ListContacts. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, opts ...ListContactsOption) iter.Seq2[Contact, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Contact], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		contacts, after, err := c.ListContacts(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[Contact]{}, err
+		}
+		return client.Page[Contact]{Results: contacts, Next: after}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *SearchContactsInput, opts ...client.CallOption) iter.Seq2[Contact, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Contact], error) {
+		searchResp, err := c.searchContacts(ctx, body, opts...)
+		if err != nil {
+			return client.Page[Contact]{}, err
+		}
+		return client.Page[Contact]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(contact Contact) string { return contact.ID })
+}
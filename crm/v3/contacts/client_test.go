@@ -568,3 +568,23 @@ func TestListContacts_InvalidJSON(t *testing.T) {
 	assert.Empty(t, nextCursor)
 	assert.Contains(t, err.Error(), "failed to unmarshal")
 }
+
+// TestAll tests that All pages with the after cursor of paging.next
+func TestAll(t *testing.T) {
+	server, contactClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if after := r.URL.Query().Get("after"); after != "" {
+			assert.Equal(t, "1", after)
+			respondJSON(w, http.StatusOK, `{"results": [{"id": "2", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": [{"id": "1", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}], "paging": {"next": {"after": "1"}}}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for contact, err := range contactClient.All(context.Background()) {
+		require.NoError(t, err)
+		ids = append(ids, contact.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
@@ -1,7 +1,9 @@
 package contacts
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -16,9 +18,19 @@ func (e *ContactNotFoundError) Error() string {
 	return fmt.Sprintf("contact %s not found", e.ContactID)
 }
 
+// Is matches client.ErrNotFound
+func (e *ContactNotFoundError) Is(target error) bool {
+	return target == client.ErrNotFound
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ContactNotFoundError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // ContactValidationError is returned on validation failures
 type ContactValidationError struct {
-	Field    string
+	Field    string // The property that failed validation, if HubSpot named one
 	Message  string
 	Original *client.HubSpotError
 }
@@ -27,37 +39,75 @@ func (e *ContactValidationError) Error() string {
 	return fmt.Sprintf("validation error on field %s: %s", e.Field, e.Message)
 }
 
+// Is matches client.ErrValidation
+func (e *ContactValidationError) Is(target error) bool {
+	return target == client.ErrValidation
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ContactValidationError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // ContactAlreadyExistsError is returned when trying to create a duplicate
 type ContactAlreadyExistsError struct {
-	ContactID string
-	Original  *client.HubSpotError
+	ContactID  string
+	ExistingID string // The ID of the existing contact, if HubSpot named it
+	Property   string // The unique property whose value conflicts, email unless HubSpot named another
+	Original   *client.HubSpotError
 }
 
 func (e *ContactAlreadyExistsError) Error() string {
-	return fmt.Sprintf("contact with email %s already exists", e.ContactID)
+	if e.ContactID != "" {
+		return fmt.Sprintf("contact with email %s already exists", e.ContactID)
+	}
+	if e.ExistingID != "" {
+		return fmt.Sprintf("contact already exists with id %s", e.ExistingID)
+	}
+	return "contact already exists"
+}
+
+// Is matches client.ErrConflict
+func (e *ContactAlreadyExistsError) Is(target error) bool {
+	return target == client.ErrConflict
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ContactAlreadyExistsError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
 }
 
 // ParseContactError converts a generic HubSpot error to a contact-specific error
 func ParseContactError(err error, contactID string) error {
-	if hubspotErr, ok := err.(*client.HubSpotError); ok {
-		switch hubspotErr.Status {
-		case 404:
-			return &ContactNotFoundError{
-				ContactID: contactID,
-				Original:  hubspotErr,
-			}
-		case 400:
-			if hubspotErr.Category == "VALIDATION_ERROR" {
-				return &ContactValidationError{
-					Field:    hubspotErr.Message,
-					Original: hubspotErr,
-				}
-			}
-		case 409:
-			return &ContactAlreadyExistsError{
+	var hubspotErr *client.HubSpotError
+	if !errors.As(err, &hubspotErr) {
+		return err
+	}
+
+	switch hubspotErr.Status {
+	case http.StatusNotFound:
+		return &ContactNotFoundError{
+			ContactID: contactID,
+			Original:  hubspotErr,
+		}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if errors.Is(hubspotErr, client.ErrValidation) {
+			return &ContactValidationError{
+				Field:    hubspotErr.Field(),
+				Message:  hubspotErr.Message,
 				Original: hubspotErr,
 			}
 		}
+	case http.StatusConflict:
+		property := hubspotErr.Field()
+		if property == "" {
+			property = "email"
+		}
+		return &ContactAlreadyExistsError{
+			ExistingID: hubspotErr.ExistingID(),
+			Property:   property,
+			Original:   hubspotErr,
+		}
 	}
 	return err
 }
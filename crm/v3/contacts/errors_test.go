@@ -73,13 +73,17 @@ func TestParseContactError_ValidationError(t *testing.T) {
 		Status:   400,
 		Category: "VALIDATION_ERROR",
 		Message:  "Invalid email format",
+		Errors:   []client.ErrorDetail{{Message: "Invalid email format", In: "email", Code: "INVALID_FORMAT"}},
 	}
 
 	result := ParseContactError(hubspotErr, "12345")
 
 	var validationErr *ContactValidationError
 	require.ErrorAs(t, result, &validationErr)
-	assert.Equal(t, "Invalid email format", validationErr.Field)
+	assert.Equal(t, "email", validationErr.Field)
+	assert.Equal(t, "Invalid email format", validationErr.Message)
+	assert.ErrorIs(t, result, client.ErrValidation)
+	assert.ErrorIs(t, result, hubspotErr)
 	assert.Equal(t, hubspotErr, validationErr.Original)
 }
 
@@ -101,7 +105,7 @@ func TestParseContactError_BadRequestNonValidation(t *testing.T) {
 func TestParseContactError_Conflict(t *testing.T) {
 	hubspotErr := &client.HubSpotError{
 		Status:  409,
-		Message: "Contact already exists",
+		Message: "Contact already exists. Existing ID: 12345",
 	}
 
 	result := ParseContactError(hubspotErr, "test@example.com")
@@ -109,6 +113,10 @@ func TestParseContactError_Conflict(t *testing.T) {
 	var existsErr *ContactAlreadyExistsError
 	require.ErrorAs(t, result, &existsErr)
 	assert.Equal(t, hubspotErr, existsErr.Original)
+	assert.Equal(t, "12345", existsErr.ExistingID)
+	assert.Equal(t, "email", existsErr.Property)
+	assert.Equal(t, "contact already exists with id 12345", existsErr.Error())
+	assert.ErrorIs(t, result, client.ErrConflict)
 }
 
 // TestParseContactError_OtherHubSpotError tests parsing other HubSpot errors
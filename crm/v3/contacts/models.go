@@ -1,5 +1,7 @@
 package contacts
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 type FilterOperator string
 
 const (
@@ -46,17 +48,14 @@ type UpdateContactInput struct {
 	Properties map[string]string `json:"properties"`
 }
 
+// ListContactsResponse represents the response from listing contacts
+type ListContactsResponse = client.CollectionResponse[Contact]
+
 // Paging represents pagination information
-type Paging struct {
-	Next *PagingLink `json:"next"`
-	Prev *PagingLink `json:"prev"`
-}
+type Paging = client.Paging
 
 // PagingLink represents a pagination link
-type PagingLink struct {
-	After string `json:"after"`
-	Link  string `json:"link"`
-}
+type PagingLink = client.PagingLink
 
 // BatchCreateContactsInput is the input for batch creating contacts
 type BatchCreateContactsInput struct {
@@ -98,8 +97,4 @@ type Filter struct {
 }
 
 // SearchContactsResponse represents a response from search
-type SearchContactsResponse struct {
-	Total   int       `json:"total"`
-	Results []Contact `json:"results"`
-	Paging  *Paging   `json:"paging"`
-}
+type SearchContactsResponse = client.CollectionResponse[Contact]
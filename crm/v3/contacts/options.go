@@ -8,7 +8,7 @@ import (
 )
 
 // GetContactOption is a functional option for GetContact
-type GetContactOption func(*client.Request)
+type GetContactOption = client.CallOption
 
 // WithProperties specifies which properties to retrieve
 func WithProperties(properties []string) GetContactOption {
@@ -39,7 +39,7 @@ func WithArchived() GetContactOption {
 }
 
 // ListContactsOption is a functional option for ListContacts
-type ListContactsOption func(*client.Request)
+type ListContactsOption = client.CallOption
 
 // WithLimit sets the maximum number of contacts to return
 func WithLimit(limit int) ListContactsOption {
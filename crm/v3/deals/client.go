@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -22,12 +24,16 @@ func NewClient(apiClient *client.Client) *Client {
 }
 
 // CreateDeal creates a new deal
-func (c *Client) CreateDeal(ctx context.Context, input *CreateDealInput) (*Deal, error) {
+func (c *Client) CreateDeal(ctx context.Context, input *CreateDealInput, opts ...client.CallOption) (*Deal, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -65,12 +71,16 @@ func (c *Client) GetDeal(ctx context.Context, dealID string, opts ...DealOption)
 }
 
 // UpdateDeal updates a deal
-func (c *Client) UpdateDeal(ctx context.Context, dealID string, input *UpdateDealInput) (*Deal, error) {
+func (c *Client) UpdateDeal(ctx context.Context, dealID string, input *UpdateDealInput, opts ...client.CallOption) (*Deal, error) {
 	req := client.NewRequest("PATCH", fmt.Sprintf("/crm/v3/objects/deals/%s", dealID))
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -84,12 +94,36 @@ func (c *Client) UpdateDeal(ctx context.Context, dealID string, input *UpdateDea
 	return &deal, nil
 }
 
+// CreateOrGetDeal creates a deal, or gets the existing deal if the create conflicts with one on a unique
+// property value. created reports whether the deal was created. opts are applied to both requests.
+func (c *Client) CreateOrGetDeal(ctx context.Context, input *CreateDealInput, opts ...client.CallOption) (deal *Deal, created bool, err error) {
+	return client.CreateOrResolve(func() (*Deal, error) {
+		return c.CreateDeal(ctx, input, opts...)
+	}, func(existingID string) (*Deal, error) {
+		return c.GetDeal(ctx, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateDeal creates a deal, or updates the properties of the existing deal if the create conflicts
+// with one on a unique property value. created reports whether the deal was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateDeal(ctx context.Context, input *CreateDealInput, opts ...client.CallOption) (deal *Deal, created bool, err error) {
+	return client.CreateOrResolve(func() (*Deal, error) {
+		return c.CreateDeal(ctx, input, opts...)
+	}, func(existingID string) (*Deal, error) {
+		return c.UpdateDeal(ctx, existingID, &UpdateDealInput{Properties: input.Properties}, opts...)
+	})
+}
+
 // ArchiveDeal archives (deletes) a deal
-func (c *Client) ArchiveDeal(ctx context.Context, dealID string) error {
+func (c *Client) ArchiveDeal(ctx context.Context, dealID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/deals/%s", dealID))
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
@@ -118,12 +152,17 @@ func (c *Client) ListDeals(ctx context.Context, opts ...DealOption) (*ListDealsR
 }
 
 // BatchReadDeals retrieves multiple deals by ID
-func (c *Client) BatchReadDeals(ctx context.Context, input *BatchReadDealsInput) (*BatchDealsResponse, error) {
+func (c *Client) BatchReadDeals(ctx context.Context, input *BatchReadDealsInput, opts ...client.CallOption) (*BatchDealsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals/batch/read")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
+	req.WithReadOnly()
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -138,12 +177,16 @@ func (c *Client) BatchReadDeals(ctx context.Context, input *BatchReadDealsInput)
 }
 
 // BatchCreateDeals creates multiple deals
-func (c *Client) BatchCreateDeals(ctx context.Context, input *BatchCreateDealsInput) (*BatchDealsResponse, error) {
+func (c *Client) BatchCreateDeals(ctx context.Context, input *BatchCreateDealsInput, opts ...client.CallOption) (*BatchDealsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals/batch/create")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -158,12 +201,16 @@ func (c *Client) BatchCreateDeals(ctx context.Context, input *BatchCreateDealsIn
 }
 
 // BatchUpdateDeals updates multiple deals
-func (c *Client) BatchUpdateDeals(ctx context.Context, input *BatchUpdateDealsInput) (*BatchDealsResponse, error) {
+func (c *Client) BatchUpdateDeals(ctx context.Context, input *BatchUpdateDealsInput, opts ...client.CallOption) (*BatchDealsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals/batch/update")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -178,22 +225,36 @@ func (c *Client) BatchUpdateDeals(ctx context.Context, input *BatchUpdateDealsIn
 }
 
 // BatchArchiveDeals archives multiple deals
-func (c *Client) BatchArchiveDeals(ctx context.Context, input *BatchArchiveDealsInput) error {
+func (c *Client) BatchArchiveDeals(ctx context.Context, input *BatchArchiveDealsInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals/batch/archive")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
 
 // SearchDeals searches for deals
-func (c *Client) SearchDeals(ctx context.Context, input *SearchDealsInput) (*SearchDealsResponse, error) {
+func (c *Client) SearchDeals(ctx context.Context, input *SearchDealsInput, opts ...client.CallOption) (*SearchDealsResponse, error) {
+	return c.searchDeals(ctx, input, opts...)
+}
+
+// searchDeals sends a search whose request body is body, which is the search input
+func (c *Client) searchDeals(ctx context.Context, body any, opts ...client.CallOption) (*SearchDealsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/deals/search")
 	req.WithContext(ctx)
 	req.WithResourceType("deals")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -207,3 +268,36 @@ func (c *Client) SearchDeals(ctx context.Context, input *SearchDealsInput) (*Sea
 
 	return &searchResp, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every deal, fetching the next page once a page has been consumed
+//
+// opts: same as ListDeals. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, opts ...DealOption) iter.Seq2[Deal, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Deal], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		listResp, err := c.ListDeals(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[Deal]{}, err
+		}
+		return client.Page[Deal]{Results: listResp.Results, Next: listResp.Paging.NextAfter()}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *SearchDealsInput, opts ...client.CallOption) iter.Seq2[Deal, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Deal], error) {
+		searchResp, err := c.searchDeals(ctx, body, opts...)
+		if err != nil {
+			return client.Page[Deal]{}, err
+		}
+		return client.Page[Deal]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(deal Deal) string { return deal.ID })
+}
@@ -1,5 +1,7 @@
 package deals
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 type FilterOperator string
 
 const (
@@ -50,22 +52,13 @@ type UpdateDealInput struct {
 }
 
 // ListDealsResponse represents the response from listing deals
-type ListDealsResponse struct {
-	Results []Deal  `json:"results"`
-	Paging  *Paging `json:"paging"`
-}
+type ListDealsResponse = client.CollectionResponse[Deal]
 
 // Paging represents pagination information
-type Paging struct {
-	Next *PagingLink `json:"next"`
-	Prev *PagingLink `json:"prev"`
-}
+type Paging = client.Paging
 
 // PagingLink represents a pagination link
-type PagingLink struct {
-	After string `json:"after"`
-	Link  string `json:"link"`
-}
+type PagingLink = client.PagingLink
 
 // BatchReadDealsInput represents input for batch read
 type BatchReadDealsInput struct {
@@ -128,8 +121,4 @@ type Filter struct {
 }
 
 // SearchDealsResponse represents response from search
-type SearchDealsResponse struct {
-	Total   int     `json:"total"`
-	Results []Deal  `json:"results"`
-	Paging  *Paging `json:"paging"`
-}
+type SearchDealsResponse = client.CollectionResponse[Deal]
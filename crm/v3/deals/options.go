@@ -8,7 +8,7 @@ import (
 )
 
 // DealOption represents a functional option for deal requests
-type DealOption func(*client.Request)
+type DealOption = client.CallOption
 
 // WithProperties specifies which properties to return
 func WithProperties(properties []string) DealOption {
@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
+	"strconv"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -66,12 +69,16 @@ func (c *Client) GetListByName(ctx context.Context, ObjectTypeID, listName strin
 	return &list.List, nil
 }
 
-func (c *Client) CreateList(ctx context.Context, input *ListCreateRequest) (*List, error) {
+func (c *Client) CreateList(ctx context.Context, input *ListCreateRequest, opts ...client.CallOption) (*List, error) {
 	req := client.NewRequest("POST", "/crm/v3/lists")
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, "")
@@ -113,12 +120,17 @@ func (c *Client) GetListsByIDs(ctx context.Context, listIDs []string, opts ...Ge
 	return listsResp.Lists, nil
 }
 
-func (c *Client) SearchLists(ctx context.Context, input *ListSearchRequest) (*ListSearchResponse, error) {
+func (c *Client) SearchLists(ctx context.Context, input *ListSearchRequest, opts ...client.CallOption) (*ListSearchResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/lists/search")
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
+	req.WithReadOnly()
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, "")
@@ -132,7 +144,7 @@ func (c *Client) SearchLists(ctx context.Context, input *ListSearchRequest) (*Li
 	return &searchResp, nil
 }
 
-func (c *Client) UpdateListName(ctx context.Context, listID, listName string, includeFilters bool) (*List, error) {
+func (c *Client) UpdateListName(ctx context.Context, listID, listName string, includeFilters bool, opts ...client.CallOption) (*List, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/update-list-name", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
@@ -142,6 +154,10 @@ func (c *Client) UpdateListName(ctx context.Context, listID, listName string, in
 		req.AddQueryParam("includeFilters", "true")
 	}
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -155,7 +171,7 @@ func (c *Client) UpdateListName(ctx context.Context, listID, listName string, in
 	return &listResp.List, nil
 }
 
-func (c *Client) UpdateListFilters(ctx context.Context, listID string, filterBranch FilterBranch, includeFilters bool) (*List, error) {
+func (c *Client) UpdateListFilters(ctx context.Context, listID string, filterBranch FilterBranch, includeFilters bool, opts ...client.CallOption) (*List, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/update-list-filters", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
@@ -168,6 +184,10 @@ func (c *Client) UpdateListFilters(ctx context.Context, listID string, filterBra
 	}
 	req.WithBody(body)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -181,11 +201,15 @@ func (c *Client) UpdateListFilters(ctx context.Context, listID string, filterBra
 	return &listResp.List, nil
 }
 
-func (c *Client) DeleteList(ctx context.Context, listID string) error {
+func (c *Client) DeleteList(ctx context.Context, listID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/lists/%s", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseListError(err, listID)
@@ -194,11 +218,15 @@ func (c *Client) DeleteList(ctx context.Context, listID string) error {
 	return nil
 }
 
-func (c *Client) RestoreList(ctx context.Context, listID string) error {
+func (c *Client) RestoreList(ctx context.Context, listID string, opts ...client.CallOption) error {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/restore", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseListError(err, listID)
@@ -207,11 +235,15 @@ func (c *Client) RestoreList(ctx context.Context, listID string) error {
 	return nil
 }
 
-func (c *Client) GetRecordMemberships(ctx context.Context, objectTypeID, recordID string) (*RecordMembershipsResponse, error) {
+func (c *Client) GetRecordMemberships(ctx context.Context, objectTypeID, recordID string, opts ...client.CallOption) (*RecordMembershipsResponse, error) {
 	req := client.NewRequest("GET", fmt.Sprintf("/crm/v3/lists/records/%s/%s/memberships", objectTypeID, recordID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseRecordError(err, recordID, "")
@@ -225,12 +257,17 @@ func (c *Client) GetRecordMemberships(ctx context.Context, objectTypeID, recordI
 	return &memberships, nil
 }
 
-func (c *Client) BatchGetRecordMemberships(ctx context.Context, inputs []MembershipRecordIdentifier) (*BatchReadMembershipsResponse, error) {
+func (c *Client) BatchGetRecordMemberships(ctx context.Context, inputs []MembershipRecordIdentifier, opts ...client.CallOption) (*BatchReadMembershipsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/lists/records/memberships/batch/read")
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
+	req.WithReadOnly()
 	req.WithBody(BatchReadMembershipsRequest{Inputs: inputs})
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, "")
@@ -244,12 +281,16 @@ func (c *Client) BatchGetRecordMemberships(ctx context.Context, inputs []Members
 	return &batchResp, nil
 }
 
-func (c *Client) AddRecordsToList(ctx context.Context, listID string, recordIDs []string) (*MembershipChangeResponse, error) {
+func (c *Client) AddRecordsToList(ctx context.Context, listID string, recordIDs []string, opts ...client.CallOption) (*MembershipChangeResponse, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/memberships/add", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 	req.WithBody(recordIDs)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -263,11 +304,15 @@ func (c *Client) AddRecordsToList(ctx context.Context, listID string, recordIDs
 	return &changeResp, nil
 }
 
-func (c *Client) AddFromSourceList(ctx context.Context, listID, sourceListID string) (*MembershipChangeResponse, error) {
+func (c *Client) AddFromSourceList(ctx context.Context, listID, sourceListID string, opts ...client.CallOption) (*MembershipChangeResponse, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/memberships/add-from/%s", listID, sourceListID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -304,11 +349,15 @@ func (c *Client) GetListMemberships(ctx context.Context, listID string, opts ...
 	return &memberships, nil
 }
 
-func (c *Client) RemoveAllRecords(ctx context.Context, listID string) error {
+func (c *Client) RemoveAllRecords(ctx context.Context, listID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/lists/%s/memberships", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseListError(err, listID)
@@ -317,12 +366,16 @@ func (c *Client) RemoveAllRecords(ctx context.Context, listID string) error {
 	return nil
 }
 
-func (c *Client) RemoveRecordsFromList(ctx context.Context, listID string, recordIDs []string) (*MembershipChangeResponse, error) {
+func (c *Client) RemoveRecordsFromList(ctx context.Context, listID string, recordIDs []string, opts ...client.CallOption) (*MembershipChangeResponse, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/memberships/remove", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 	req.WithBody(recordIDs)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -336,12 +389,16 @@ func (c *Client) RemoveRecordsFromList(ctx context.Context, listID string, recor
 	return &changeResp, nil
 }
 
-func (c *Client) ScheduleConversion(ctx context.Context, listID string, conversionReq *ScheduleConversionRequest) (*ScheduleConversionResponse, error) {
+func (c *Client) ScheduleConversion(ctx context.Context, listID string, conversionReq *ScheduleConversionRequest, opts ...client.CallOption) (*ScheduleConversionResponse, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v3/lists/%s/schedule-conversion", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 	req.WithBody(conversionReq)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -355,11 +412,15 @@ func (c *Client) ScheduleConversion(ctx context.Context, listID string, conversi
 	return &conversionResp, nil
 }
 
-func (c *Client) GetConversionSchedule(ctx context.Context, listID string) (*ScheduleConversionResponse, error) {
+func (c *Client) GetConversionSchedule(ctx context.Context, listID string, opts ...client.CallOption) (*ScheduleConversionResponse, error) {
 	req := client.NewRequest("GET", fmt.Sprintf("/crm/v3/lists/%s/schedule-conversion", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseListError(err, listID)
@@ -373,11 +434,15 @@ func (c *Client) GetConversionSchedule(ctx context.Context, listID string) (*Sch
 	return &conversionResp, nil
 }
 
-func (c *Client) DeleteConversionSchedule(ctx context.Context, listID string) error {
+func (c *Client) DeleteConversionSchedule(ctx context.Context, listID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/lists/%s/schedule-conversion", listID))
 	req.WithContext(ctx)
 	req.WithResourceType("lists")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseListError(err, listID)
@@ -385,3 +450,54 @@ func (c *Client) DeleteConversionSchedule(ctx context.Context, listID string) er
 
 	return nil
 }
+
+// -------- Iterators --------
+
+// SearchAll iterates over every list matching a search, fetching the next page once a page has been consumed.
+// input.Offset sets the offset of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *ListSearchRequest, opts ...client.CallOption) iter.Seq2[List, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[List], error) {
+		page := *input
+		if cursor != "" {
+			offset, err := strconv.Atoi(cursor)
+			if err != nil {
+				return client.Page[List]{}, fmt.Errorf("invalid list search offset %q: %w", cursor, err)
+			}
+			page.Offset = &offset
+		}
+
+		searchResp, err := c.SearchLists(ctx, &page, opts...)
+		if err != nil {
+			return client.Page[List]{}, err
+		}
+
+		next := ""
+		if searchResp.HasMore {
+			next = strconv.Itoa(searchResp.Offset)
+		}
+		return client.Page[List]{Results: searchResp.Lists, Next: next}, nil
+	})
+}
+
+// AllMemberships iterates over the IDs of every record in a list, fetching the next page once a page has been consumed
+//
+// opts: same as GetListMemberships. WithMembershipsOffset sets the offset of the first page.
+func (c *Client) AllMemberships(ctx context.Context, listID string, opts ...ListMembershipsOption) iter.Seq2[string, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[string], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithMembershipsOffset(cursor))
+		}
+
+		memberships, err := c.GetListMemberships(ctx, listID, pageOpts...)
+		if err != nil {
+			return client.Page[string]{}, err
+		}
+
+		next := ""
+		if memberships.HasMore != nil && *memberships.HasMore && memberships.Offset != nil {
+			next = *memberships.Offset
+		}
+		return client.Page[string]{Results: memberships.Results, Next: next}, nil
+	})
+}
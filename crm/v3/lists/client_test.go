@@ -1038,3 +1038,45 @@ func TestGetConversionSchedule_InvalidJSON(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to unmarshal")
 }
+
+// TestSearchAll tests that SearchAll pages with the offset of the search response
+func TestSearchAll(t *testing.T) {
+	server, listClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body ListSearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body.Offset != nil {
+			assert.Equal(t, 1, *body.Offset)
+			respondJSON(w, http.StatusOK, `{"lists": [{"listId": "2"}], "hasMore": false, "offset": 2}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"lists": [{"listId": "1"}], "hasMore": true, "offset": 1}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for list, err := range listClient.SearchAll(context.Background(), &ListSearchRequest{}) {
+		require.NoError(t, err)
+		ids = append(ids, list.ListID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+// TestAllMemberships tests that AllMemberships pages with the offset of the memberships response
+func TestAllMemberships(t *testing.T) {
+	server, listClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if offset := r.URL.Query().Get("offset"); offset != "" {
+			assert.Equal(t, "101", offset)
+			respondJSON(w, http.StatusOK, `{"results": ["102"], "hasMore": false}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": ["101"], "hasMore": true, "offset": "101"}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for id, err := range listClient.AllMemberships(context.Background(), "123") {
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"101", "102"}, ids)
+}
@@ -1,7 +1,9 @@
 package lists
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -16,9 +18,19 @@ func (e *ListNotFoundError) Error() string {
 	return fmt.Sprintf("list %s not found", e.ListID)
 }
 
+// Is matches client.ErrNotFound
+func (e *ListNotFoundError) Is(target error) bool {
+	return target == client.ErrNotFound
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ListNotFoundError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // ListValidationError is returned on validation failures
 type ListValidationError struct {
-	Field    string
+	Field    string // The field that failed validation, if HubSpot named one
 	Message  string
 	Original *client.HubSpotError
 }
@@ -27,6 +39,16 @@ func (e *ListValidationError) Error() string {
 	return fmt.Sprintf("validation error on field %s: %s", e.Field, e.Message)
 }
 
+// Is matches client.ErrValidation
+func (e *ListValidationError) Is(target error) bool {
+	return target == client.ErrValidation
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ListValidationError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // ListAlreadyExistsError is returned when trying to create a duplicate
 type ListAlreadyExistsError struct {
 	ListName string
@@ -37,6 +59,16 @@ func (e *ListAlreadyExistsError) Error() string {
 	return fmt.Sprintf("list with name %s already exists", e.ListName)
 }
 
+// Is matches client.ErrConflict
+func (e *ListAlreadyExistsError) Is(target error) bool {
+	return target == client.ErrConflict
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ListAlreadyExistsError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // RecordNotFoundError is returned when a record is not found in a list
 type RecordNotFoundError struct {
 	RecordID string
@@ -48,40 +80,53 @@ func (e *RecordNotFoundError) Error() string {
 	return fmt.Sprintf("record %s not found in list %s", e.RecordID, e.ListID)
 }
 
+// Is matches client.ErrNotFound
+func (e *RecordNotFoundError) Is(target error) bool {
+	return target == client.ErrNotFound
+}
+
+// Unwrap returns the original HubSpot error
+func (e *RecordNotFoundError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
 // ParseListError converts a generic HubSpot error to a list-specific error
 func ParseListError(err error, listID string) error {
-	if hubspotErr, ok := err.(*client.HubSpotError); ok {
-		switch hubspotErr.Status {
-		case 404:
-			return &ListNotFoundError{
-				ListID:   listID,
-				Original: hubspotErr,
-			}
-		case 400:
-			if hubspotErr.Category == "VALIDATION_ERROR" {
-				return &ListValidationError{
-					Field:    hubspotErr.Message,
-					Original: hubspotErr,
-				}
-			}
-		case 409:
-			return &ListAlreadyExistsError{
+	var hubspotErr *client.HubSpotError
+	if !errors.As(err, &hubspotErr) {
+		return err
+	}
+
+	switch hubspotErr.Status {
+	case http.StatusNotFound:
+		return &ListNotFoundError{
+			ListID:   listID,
+			Original: hubspotErr,
+		}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if errors.Is(hubspotErr, client.ErrValidation) {
+			return &ListValidationError{
+				Field:    hubspotErr.Field(),
+				Message:  hubspotErr.Message,
 				Original: hubspotErr,
 			}
 		}
+	case http.StatusConflict:
+		return &ListAlreadyExistsError{
+			Original: hubspotErr,
+		}
 	}
 	return err
 }
 
 // ParseRecordError converts a generic HubSpot error to a record-specific error
 func ParseRecordError(err error, recordID, listID string) error {
-	if hubspotErr, ok := err.(*client.HubSpotError); ok {
-		if hubspotErr.Status == 404 {
-			return &RecordNotFoundError{
-				RecordID: recordID,
-				ListID:   listID,
-				Original: hubspotErr,
-			}
+	var hubspotErr *client.HubSpotError
+	if errors.As(err, &hubspotErr) && hubspotErr.Status == http.StatusNotFound {
+		return &RecordNotFoundError{
+			RecordID: recordID,
+			ListID:   listID,
+			Original: hubspotErr,
 		}
 	}
 	return ParseListError(err, listID)
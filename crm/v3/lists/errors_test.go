@@ -88,13 +88,17 @@ func TestParseListError_ValidationError(t *testing.T) {
 		Status:   400,
 		Category: "VALIDATION_ERROR",
 		Message:  "Invalid list name",
+		Errors:   []client.ErrorDetail{{Message: "Invalid list name", In: "name", Code: "INVALID_FORMAT"}},
 	}
 
 	result := ParseListError(hubspotErr, "123")
 
 	var validationErr *ListValidationError
 	require.ErrorAs(t, result, &validationErr)
-	assert.Equal(t, "Invalid list name", validationErr.Field)
+	assert.Equal(t, "name", validationErr.Field)
+	assert.Equal(t, "Invalid list name", validationErr.Message)
+	assert.ErrorIs(t, result, client.ErrValidation)
+	assert.ErrorIs(t, result, hubspotErr)
 	assert.Equal(t, hubspotErr, validationErr.Original)
 }
 
@@ -7,7 +7,7 @@ import (
 )
 
 // GetListOption is a functional option for GetList methods (GetListById, GetListByName, GetListsByIDs)
-type GetListOption func(*client.Request)
+type GetListOption = client.CallOption
 
 // WithIncludeFilters includes filter definitions in the response
 func WithIncludeFilters(includeFilters bool) GetListOption {
@@ -19,7 +19,7 @@ func WithIncludeFilters(includeFilters bool) GetListOption {
 }
 
 // ListMembershipsOption is a functional option for GetListMemberships
-type ListMembershipsOption func(*client.Request)
+type ListMembershipsOption = client.CallOption
 
 // WithMembershipsLimit sets the maximum number of membership results to return
 func WithMembershipsLimit(limit int) ListMembershipsOption {
@@ -5,9 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
-	"github.com/josiah-hester/go-hubspot-sdk/internal/tools"
 )
 
 type Client struct {
@@ -52,20 +53,54 @@ func (c *Client) ListObjects(ctx context.Context, objectType string, opts ...Obj
 		return nil, nil, fmt.Errorf("failed to unmarshal object response: %w", err)
 	}
 
-	if len(objResp.Results) > 0 {
-		return objResp.Results, &objResp.Paging, nil
+	return objResp.Results, &objResp.Paging, nil
+}
+
+// StreamObjects lists a page of HubSpot objects like ListObjects, passing each object to fn as it is decoded
+// instead of holding the whole page in memory. It stops at the first error returned by fn.
+//
+// opts: same as ListObjects
+func (c *Client) StreamObjects(ctx context.Context, objectType string, fn func(Object) error, opts ...ObjectsOption) (*Paging, error) {
+	req := client.NewRequest("GET", fmt.Sprintf("/crm/v3/objects/%s", objectType))
+	req.WithContext(ctx)
+	req.WithResourceType("objects")
+
+	// Apply options
+	for _, opt := range opts {
+		opt(req)
 	}
+	client.WithStreaming()(req)
 
-	return nil, nil, fmt.Errorf("no objects found for type %s", objectType)
+	resp, err := c.apiClient.Do(ctx, req)
+	if err != nil {
+		return nil, ParseObjectError(err, objectType)
+	}
+	defer resp.Close()
+
+	fields, err := client.DecodeResults(resp.Reader(), fn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode object response: %w", err)
+	}
+
+	var paging Paging
+	if err := client.DecodeField(fields, "paging", &paging); err != nil {
+		return nil, err
+	}
+
+	return &paging, nil
 }
 
 // CreateObject creates a new HubSpot object
-func (c *Client) CreateObject(ctx context.Context, input *CreateObjectInput, objectType string) (*Object, error) {
+func (c *Client) CreateObject(ctx context.Context, input *CreateObjectInput, objectType string, opts ...client.CallOption) (*Object, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -138,12 +173,37 @@ func (c *Client) UpdateObject(ctx context.Context, objectType string, id string,
 	return &obj, nil
 }
 
+// CreateOrGetObject creates a HubSpot object, or reads the existing object if the create conflicts with one on a
+// unique property value. created reports whether the object was created. opts are applied to both requests.
+func (c *Client) CreateOrGetObject(ctx context.Context, input *CreateObjectInput, objectType string, opts ...client.CallOption) (obj *Object, created bool, err error) {
+	return client.CreateOrResolve(func() (*Object, error) {
+		return c.CreateObject(ctx, input, objectType, opts...)
+	}, func(existingID string) (*Object, error) {
+		return c.ReadObject(ctx, objectType, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateObject creates a HubSpot object, or updates the properties of the existing object if the create
+// conflicts with one on a unique property value. The associations of input are only applied when the object is
+// created. created reports whether the object was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateObject(ctx context.Context, input *CreateObjectInput, objectType string, opts ...client.CallOption) (obj *Object, created bool, err error) {
+	return client.CreateOrResolve(func() (*Object, error) {
+		return c.CreateObject(ctx, input, objectType, opts...)
+	}, func(existingID string) (*Object, error) {
+		return c.UpdateObject(ctx, objectType, existingID, &UpdateObjectInput{Properties: input.Properties}, opts...)
+	})
+}
+
 // ArchiveObject archives a HubSpot object by id
-func (c *Client) ArchiveObject(ctx context.Context, objectType string, id string) error {
+func (c *Client) ArchiveObject(ctx context.Context, objectType string, id string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/%s/%s", objectType, id))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return ParseObjectError(err, objectType)
@@ -153,12 +213,16 @@ func (c *Client) ArchiveObject(ctx context.Context, objectType string, id string
 }
 
 // MergeObjects merges two HubSpot objects by id
-func (c *Client) MergeObjects(ctx context.Context, objectType string, input *MergeObjectsInput) (*Object, error) {
+func (c *Client) MergeObjects(ctx context.Context, objectType string, input *MergeObjectsInput, opts ...client.CallOption) (*Object, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/merge", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -182,6 +246,7 @@ func (c *Client) BatchReadObjects(ctx context.Context, objectType string, input
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/batch/read", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
+	req.WithReadOnly()
 	req.WithBody(input)
 
 	// Apply options
@@ -215,12 +280,16 @@ func (c *Client) BatchReadObjects(ctx context.Context, objectType string, input
 }
 
 // BatchCreateObjects creates a batch of HubSpot objects
-func (c *Client) BatchCreateObjects(ctx context.Context, objectType string, input *BatchCreateObjectsInput) (*BatchResponse, error) {
+func (c *Client) BatchCreateObjects(ctx context.Context, objectType string, input *BatchCreateObjectsInput, opts ...client.CallOption) (*BatchResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/batch/create", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -247,12 +316,16 @@ func (c *Client) BatchCreateObjects(ctx context.Context, objectType string, inpu
 }
 
 // BatchUpdateObjects updates a batch of HubSpot objects
-func (c *Client) BatchUpdateObjects(ctx context.Context, objectType string, input *BatchUpdateObjectsInput) (*BatchResponse, error) {
+func (c *Client) BatchUpdateObjects(ctx context.Context, objectType string, input *BatchUpdateObjectsInput, opts ...client.CallOption) (*BatchResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/batch/update", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -279,12 +352,16 @@ func (c *Client) BatchUpdateObjects(ctx context.Context, objectType string, inpu
 }
 
 // BatchCreateOrUpdateObjects creates or updates a batch of HubSpot objects
-func (c *Client) BatchCreateOrUpdateObjects(ctx context.Context, objectType string, input *BatchCreateOrUpdateObjectsInput) (*BatchResponse, error) {
+func (c *Client) BatchCreateOrUpdateObjects(ctx context.Context, objectType string, input *BatchCreateOrUpdateObjectsInput, opts ...client.CallOption) (*BatchResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/batch/upsert", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -311,12 +388,16 @@ func (c *Client) BatchCreateOrUpdateObjects(ctx context.Context, objectType stri
 }
 
 // BatchArchiveObjects archives a batch of HubSpot objects
-func (c *Client) BatchArchiveObjects(ctx context.Context, objectType string, input *BatchArchiveObjectsInput) (*BatchResponse, error) {
+func (c *Client) BatchArchiveObjects(ctx context.Context, objectType string, input *BatchArchiveObjectsInput, opts ...client.CallOption) (*BatchResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/batch/archive", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, ParseObjectError(err, objectType)
@@ -345,11 +426,21 @@ func (c *Client) BatchArchiveObjects(ctx context.Context, objectType string, inp
 // -------- Search Methods --------
 
 // SearchObjects searches for HubSpot objects
-func (c *Client) SearchObjects(ctx context.Context, objectType string, input *SearchObjectsInput) (*SearchObjectsResponse, error) {
+func (c *Client) SearchObjects(ctx context.Context, objectType string, input *SearchObjectsInput, opts ...client.CallOption) (*SearchObjectsResponse, error) {
+	return c.searchObjects(ctx, objectType, input, opts...)
+}
+
+// searchObjects fetches a page of search results, which may be empty. body is the search input.
+func (c *Client) searchObjects(ctx context.Context, objectType string, body any, opts ...client.CallOption) (*SearchObjectsResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/search", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("objects")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -357,13 +448,79 @@ func (c *Client) SearchObjects(ctx context.Context, objectType string, input *Se
 	}
 
 	var obj SearchObjectsResponse
-	if err := tools.NewRequiredTagStruct(&obj).UnmarhsalJSON(resp.Body); err != nil {
+	if err := json.Unmarshal(resp.Body, &obj); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal object response: %w", err)
 	}
 
-	if len(obj.Results) == 0 {
-		return nil, fmt.Errorf("no results found in search request")
+	return &obj, nil
+}
+
+// StreamSearchObjects searches for HubSpot objects like SearchObjects, passing each result to fn as it is decoded.
+// It returns the total number of matches and the paging of the page, and stops at the first error returned by fn.
+func (c *Client) StreamSearchObjects(ctx context.Context, objectType string, input *SearchObjectsInput, fn func(Object) error, opts ...client.CallOption) (int, *Paging, error) {
+	req := client.NewRequest("POST", fmt.Sprintf("/crm/v3/objects/%s/search", objectType))
+	req.WithContext(ctx)
+	req.WithResourceType("objects")
+	req.WithReadOnly()
+	req.WithBody(input)
+
+	for _, opt := range opts {
+		opt(req)
 	}
+	client.WithStreaming()(req)
 
-	return &obj, nil
+	resp, err := c.apiClient.Do(ctx, req)
+	if err != nil {
+		return 0, nil, ParseObjectError(err, objectType)
+	}
+	defer resp.Close()
+
+	fields, err := client.DecodeResults(resp.Reader(), fn)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+
+	var total int
+	if err := client.DecodeField(fields, "total", &total); err != nil {
+		return 0, nil, err
+	}
+	var paging Paging
+	if err := client.DecodeField(fields, "paging", &paging); err != nil {
+		return 0, nil, err
+	}
+
+	return total, &paging, nil
+}
+
+// -------- Iterators --------
+
+// All iterates over every HubSpot object of objectType, fetching the next page once a page has been consumed
+//
+// opts: same as ListObjects. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, objectType string, opts ...ObjectsOption) iter.Seq2[Object, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Object], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		objects, paging, err := c.ListObjects(ctx, objectType, pageOpts...)
+		if err != nil {
+			return client.Page[Object]{}, err
+		}
+		return client.Page[Object]{Results: objects, Next: paging.NextAfter()}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, objectType string, input *SearchObjectsInput, opts ...client.CallOption) iter.Seq2[Object, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Object], error) {
+		searchResp, err := c.searchObjects(ctx, objectType, body, opts...)
+		if err != nil {
+			return client.Page[Object]{}, err
+		}
+		return client.Page[Object]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(obj Object) string { return obj.ID })
 }
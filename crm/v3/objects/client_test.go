@@ -210,7 +210,7 @@ func TestListObjects_WithArchived(t *testing.T) {
 	assert.True(t, objects[0].Archived)
 }
 
-// TestListObjects_NoResults tests when no objects found
+// TestListObjects_NoResults tests that an empty page is not an error
 func TestListObjects_NoResults(t *testing.T) {
 	objectJSON := `{
 		"results": []
@@ -221,11 +221,13 @@ func TestListObjects_NoResults(t *testing.T) {
 	})
 	defer server.Close()
 
-	objects, _, err := objectClient.ListObjects(context.Background(), "contacts")
+	objects, paging, err := objectClient.ListObjects(context.Background(), "contacts")
 
-	require.Error(t, err)
-	assert.Nil(t, objects)
-	assert.Contains(t, err.Error(), "no objects found")
+	require.NoError(t, err)
+	assert.NotNil(t, objects)
+	assert.Empty(t, objects)
+	require.NotNil(t, paging)
+	assert.Empty(t, paging.NextAfter())
 }
 
 // TestListObjects_InvalidJSON tests invalid JSON response
@@ -389,6 +391,41 @@ func TestReadObject_WithOptions(t *testing.T) {
 	assert.NotNil(t, object)
 }
 
+// TestReadObject_WithCallOptions tests ReadObject with generic call options alongside object options
+func TestReadObject_WithCallOptions(t *testing.T) {
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "email", r.URL.Query().Get("properties"))
+		assert.Equal(t, "sync-job", r.Header.Get("X-Request-Source"))
+		respondJSON(w, http.StatusOK, `{"id": "1234567890", "properties": {"email": "test@example.com"}}`)
+	})
+	defer server.Close()
+
+	object, err := objectClient.ReadObject(
+		context.Background(),
+		"contacts",
+		"1234567890",
+		WithProperties([]string{"email"}),
+		client.WithHeader("X-Request-Source", "sync-job"),
+		client.WithCallTimeout(time.Second),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", object.ID)
+}
+
+// TestArchiveObject_WithCallOptions tests that methods without resource options accept call options
+func TestArchiveObject_WithCallOptions(t *testing.T) {
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sync-job", r.Header.Get("X-Request-Source"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	err := objectClient.ArchiveObject(context.Background(), "contacts", "1234567890", client.WithHeader("X-Request-Source", "sync-job"))
+
+	assert.NoError(t, err)
+}
+
 // TestReadObject_NotFound tests 404 error handling
 func TestReadObject_NotFound(t *testing.T) {
 	errorJSON := `{
@@ -1533,7 +1570,7 @@ func TestSearchObjects_WithFilters(t *testing.T) {
 	assert.Equal(t, 1, result.Total)
 }
 
-// TestSearchObjects_NoResults tests search with no results
+// TestSearchObjects_NoResults tests that a search without matches is not an error
 func TestSearchObjects_NoResults(t *testing.T) {
 	responseJSON := `{
 		"total": 0,
@@ -1563,8 +1600,11 @@ func TestSearchObjects_NoResults(t *testing.T) {
 
 	result, err := objectClient.SearchObjects(context.Background(), "contacts", input)
 
-	require.Error(t, err)
-	assert.Nil(t, result)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 0, result.Total)
+	assert.NotNil(t, result.Results)
+	assert.Empty(t, result.Results)
 }
 
 // TestSearchObjects_InvalidJSON tests invalid JSON response
@@ -1597,3 +1637,97 @@ func TestSearchObjects_InvalidJSON(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "failed to unmarshal")
 }
+
+// TestStreamObjects_Success tests streaming a page of objects
+func TestStreamObjects_Success(t *testing.T) {
+	responseJSON := `{
+		"results": [
+			{"id": "1", "properties": {"email": "test1@example.com"}},
+			{"id": "2", "properties": {"email": "test2@example.com"}}
+		],
+		"paging": {"next": {"after": "2"}}
+	}`
+
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/crm/v3/objects/contacts", r.URL.Path)
+		assert.Equal(t, "email", r.URL.Query().Get("properties"))
+		respondJSON(w, http.StatusOK, responseJSON)
+	})
+	defer server.Close()
+
+	var ids []string
+	paging, err := objectClient.StreamObjects(context.Background(), "contacts", func(object Object) error {
+		ids = append(ids, object.ID)
+		return nil
+	}, WithProperties([]string{"email"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, "2", paging.Next.After)
+}
+
+// TestStreamObjects_NotFound tests that error responses are parsed when streaming
+func TestStreamObjects_NotFound(t *testing.T) {
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusNotFound, `{"status": "error", "message": "Unknown object type", "category": "OBJECT_NOT_FOUND"}`)
+	})
+	defer server.Close()
+
+	_, err := objectClient.StreamObjects(context.Background(), "p_missing", func(object Object) error {
+		t.Fatal("unexpected object")
+		return nil
+	})
+
+	var notFound *ObjectNotFoundError
+	require.ErrorAs(t, err, &notFound)
+}
+
+// TestStreamSearchObjects_Success tests streaming search results
+func TestStreamSearchObjects_Success(t *testing.T) {
+	responseJSON := `{
+		"total": 3,
+		"results": [
+			{"id": "1", "properties": {"email": "test1@example.com"}},
+			{"id": "2", "properties": {"email": "test2@example.com"}}
+		],
+		"paging": {"next": {"after": "2"}}
+	}`
+
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/crm/v3/objects/contacts/search", r.URL.Path)
+		respondJSON(w, http.StatusOK, responseJSON)
+	})
+	defer server.Close()
+
+	var emails []string
+	total, paging, err := objectClient.StreamSearchObjects(context.Background(), "contacts", &SearchObjectsInput{Limit: 2}, func(object Object) error {
+		emails = append(emails, object.Properties["email"])
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"test1@example.com", "test2@example.com"}, emails)
+	assert.Equal(t, "2", paging.Next.After)
+}
+
+// TestAll tests that All pages with the after cursor of paging.next
+func TestAll(t *testing.T) {
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if after := r.URL.Query().Get("after"); after != "" {
+			assert.Equal(t, "1", after)
+			respondJSON(w, http.StatusOK, `{"results": [{"id": "2", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": [{"id": "1", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}], "paging": {"next": {"after": "1"}}}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for obj, err := range objectClient.All(context.Background(), "contacts") {
+		require.NoError(t, err)
+		ids = append(ids, obj.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
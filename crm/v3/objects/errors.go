@@ -1,7 +1,9 @@
 package objects
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -9,15 +11,30 @@ import (
 // ObjectNotFoundError is returned when an object is not found
 type ObjectNotFoundError struct {
 	ObjectType string
+	ID         string // Set for objects missing from a batch read
 	Original   *client.HubSpotError
 }
 
 func (e *ObjectNotFoundError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("object %s %s not found", e.ObjectType, e.ID)
+	}
 	return fmt.Sprintf("object %s not found", e.ObjectType)
 }
 
+// Is matches client.ErrNotFound
+func (e *ObjectNotFoundError) Is(target error) bool {
+	return target == client.ErrNotFound
+}
+
+// Unwrap returns the original HubSpot error, which is nil for objects missing from a batch read
+func (e *ObjectNotFoundError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
+// ObjectValidationError is returned on validation failures
 type ObjectValidationError struct {
-	Field    string
+	Field    string // The property that failed validation, if HubSpot named one
 	Message  string
 	Original *client.HubSpotError
 }
@@ -26,35 +43,67 @@ func (e *ObjectValidationError) Error() string {
 	return fmt.Sprintf("validation error on field %s: %s", e.Field, e.Message)
 }
 
+// Is matches client.ErrValidation
+func (e *ObjectValidationError) Is(target error) bool {
+	return target == client.ErrValidation
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ObjectValidationError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
+// ObjectAlreadyExistsError is returned when trying to create a duplicate
 type ObjectAlreadyExistsError struct {
-	ObjectID string
+	ObjectID string // The ID of the existing object, if HubSpot named it
+	Property string // The unique property whose value conflicts, if HubSpot named it
 	Original *client.HubSpotError
 }
 
 func (e *ObjectAlreadyExistsError) Error() string {
+	if e.ObjectID == "" {
+		return "object already exists"
+	}
 	return fmt.Sprintf("object with id %s already exists", e.ObjectID)
 }
 
+// Is matches client.ErrConflict
+func (e *ObjectAlreadyExistsError) Is(target error) bool {
+	return target == client.ErrConflict
+}
+
+// Unwrap returns the original HubSpot error
+func (e *ObjectAlreadyExistsError) Unwrap() error {
+	return client.ErrorOrNil(e.Original)
+}
+
+// ParseObjectError converts a generic HubSpot error to an object-specific error
 func ParseObjectError(err error, objectType string) error {
-	if hubspotErr, ok := err.(*client.HubSpotError); ok {
-		switch hubspotErr.Status {
-		case 404:
-			return &ObjectNotFoundError{
-				ObjectType: objectType,
-				Original:   hubspotErr,
-			}
-		case 400:
-			if hubspotErr.Category == "VALIDATION_ERROR" {
-				return &ObjectValidationError{
-					Field:    hubspotErr.Message,
-					Original: hubspotErr,
-				}
-			}
-		case 409:
-			return &ObjectAlreadyExistsError{
+	var hubspotErr *client.HubSpotError
+	if !errors.As(err, &hubspotErr) {
+		return err
+	}
+
+	switch hubspotErr.Status {
+	case http.StatusNotFound:
+		return &ObjectNotFoundError{
+			ObjectType: objectType,
+			Original:   hubspotErr,
+		}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if errors.Is(hubspotErr, client.ErrValidation) {
+			return &ObjectValidationError{
+				Field:    hubspotErr.Field(),
+				Message:  hubspotErr.Message,
 				Original: hubspotErr,
 			}
 		}
+	case http.StatusConflict:
+		return &ObjectAlreadyExistsError{
+			ObjectID: hubspotErr.ExistingID(),
+			Property: hubspotErr.Field(),
+			Original: hubspotErr,
+		}
 	}
 	return err
 }
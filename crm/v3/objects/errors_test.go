@@ -2,6 +2,7 @@ package objects
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
@@ -88,13 +89,17 @@ func TestParseObjectError_ValidationError(t *testing.T) {
 		Status:   400,
 		Category: "VALIDATION_ERROR",
 		Message:  "Invalid email format",
+		Errors:   []client.ErrorDetail{{Message: "Invalid email format", In: "email", Code: "INVALID_FORMAT"}},
 	}
 
 	result := ParseObjectError(hubspotErr, "contacts")
 
 	var validationErr *ObjectValidationError
 	require.ErrorAs(t, result, &validationErr)
-	assert.Equal(t, "Invalid email format", validationErr.Field)
+	assert.Equal(t, "email", validationErr.Field)
+	assert.Equal(t, "Invalid email format", validationErr.Message)
+	assert.ErrorIs(t, result, client.ErrValidation)
+	assert.ErrorIs(t, result, hubspotErr)
 	assert.Equal(t, hubspotErr, validationErr.Original)
 }
 
@@ -116,7 +121,8 @@ func TestParseObjectError_BadRequestNonValidation(t *testing.T) {
 func TestParseObjectError_Conflict(t *testing.T) {
 	hubspotErr := &client.HubSpotError{
 		Status:  409,
-		Message: "Object already exists",
+		Message: "Object already exists. Existing ID: 12345",
+		Context: map[string][]string{"propertyName": {"email"}},
 	}
 
 	result := ParseObjectError(hubspotErr, "contacts")
@@ -124,6 +130,9 @@ func TestParseObjectError_Conflict(t *testing.T) {
 	var existsErr *ObjectAlreadyExistsError
 	require.ErrorAs(t, result, &existsErr)
 	assert.Equal(t, hubspotErr, existsErr.Original)
+	assert.Equal(t, "12345", existsErr.ObjectID)
+	assert.Equal(t, "email", existsErr.Property)
+	assert.Equal(t, "object with id 12345 already exists", existsErr.Error())
 }
 
 // TestParseObjectError_OtherHubSpotError tests parsing other HubSpot errors
@@ -250,3 +259,39 @@ func TestBatchError_Error_EmptyMessageNoErrors(t *testing.T) {
 	expectedMsg := "batch error"
 	assert.Equal(t, expectedMsg, err.Error())
 }
+
+// TestObjectErrors_Sentinels tests matching object errors with the client sentinel errors
+func TestObjectErrors_Sentinels(t *testing.T) {
+	t.Run("Not found wraps the original error", func(t *testing.T) {
+		hubspotErr := &client.HubSpotError{Status: 404, Message: "Object not found"}
+		err := ParseObjectError(hubspotErr, "contacts")
+
+		assert.ErrorIs(t, err, client.ErrNotFound)
+		assert.ErrorIs(t, err, hubspotErr)
+		assert.NotErrorIs(t, err, client.ErrConflict)
+	})
+
+	t.Run("Not found without an original error", func(t *testing.T) {
+		err := error(&ObjectNotFoundError{ObjectType: "contacts", ID: "1"})
+
+		assert.ErrorIs(t, err, client.ErrNotFound)
+		assert.Nil(t, errors.Unwrap(err))
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		err := ParseObjectError(&client.HubSpotError{Status: 409, Category: "CONFLICT"}, "contacts")
+		assert.ErrorIs(t, err, client.ErrConflict)
+	})
+
+	t.Run("Wrapped HubSpot error", func(t *testing.T) {
+		wrapped := fmt.Errorf("request failed: %w", &client.HubSpotError{Status: 404})
+		var notFoundErr *ObjectNotFoundError
+		require.ErrorAs(t, ParseObjectError(wrapped, "contacts"), &notFoundErr)
+	})
+
+	t.Run("Batch error category", func(t *testing.T) {
+		err := error(&BatchError{Category: "OBJECT_NOT_FOUND", Message: "Object not found"})
+		assert.ErrorIs(t, err, client.ErrNotFound)
+		assert.NotErrorIs(t, err, client.ErrValidation)
+	})
+}
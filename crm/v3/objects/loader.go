@@ -0,0 +1,244 @@
+package objects
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBatchReadSize is the most inputs HubSpot accepts in a batch read
+const maxBatchReadSize = 100
+
+// LoaderConfig configures a Loader
+type LoaderConfig struct {
+	// Wait is how long a batch collects reads before it is sent, defaults to 10ms
+	Wait time.Duration
+
+	// MaxBatchSize sends a batch as soon as it holds this many IDs, defaults to and is capped at 100
+	MaxBatchSize int
+
+	// IDProperty identifies objects by a unique property instead of their object ID. It is added to every batch read.
+	IDProperty string
+
+	// CaseInsensitiveID matches IDProperty values regardless of case, as HubSpot does for email. It is implied
+	// when IDProperty is "email".
+	CaseInsensitiveID bool
+
+	// Options are applied to every batch read, e.g. client.WithCallTimeout
+	Options []ObjectsOption
+}
+
+// Loader coalesces concurrent single-object reads into batch reads
+//
+// Reads of one object type made within the Wait window are sent as a single BatchReadObjects
+// requesting the union of their properties, and each object is trimmed back to the properties
+// requested for it. Reads that request no properties are batched apart and get HubSpot's default
+// properties like ReadObject, or only the ID property with an IDProperty. Reads of the same ID in
+// one batch share its result.
+type Loader struct {
+	client     *Client
+	objectType string
+	config     LoaderConfig
+
+	mu      sync.Mutex
+	batches map[bool]*loaderBatch // keyed by whether the batch reads default properties
+}
+
+// loaderBatch is a batch read collecting IDs
+type loaderBatch struct {
+	ctx        context.Context
+	defaults   bool
+	ids        []string
+	properties []string
+	waiters    map[string][]loaderWaiter // keyed by idKey
+	timer      *time.Timer
+}
+
+// loaderWaiter is a caller waiting for an object
+type loaderWaiter struct {
+	id         string
+	properties []string
+	result     chan loaderResult
+}
+
+// loaderResult is the outcome of a read for one ID
+type loaderResult struct {
+	object *Object
+	err    error
+}
+
+// NewLoader creates a Loader reading objects of objectType through objectsClient
+func NewLoader(objectsClient *Client, objectType string, cfg LoaderConfig) *Loader {
+	if cfg.Wait <= 0 {
+		cfg.Wait = 10 * time.Millisecond
+	}
+	if cfg.MaxBatchSize <= 0 || cfg.MaxBatchSize > maxBatchReadSize {
+		cfg.MaxBatchSize = maxBatchReadSize
+	}
+
+	if cfg.IDProperty == "email" {
+		cfg.CaseInsensitiveID = true
+	}
+
+	return &Loader{
+		client:     objectsClient,
+		objectType: objectType,
+		config:     cfg,
+		batches:    make(map[bool]*loaderBatch),
+	}
+}
+
+// Load reads an object through the next batch read. Objects missing from the batch return an *ObjectNotFoundError.
+//
+// Cancelling ctx abandons the read without cancelling the batch, which other callers may be waiting for.
+func (l *Loader) Load(ctx context.Context, id string, properties ...string) (*Object, error) {
+	result := make(chan loaderResult, 1)
+
+	defaults := len(properties) == 0
+	key := l.idKey(id)
+
+	l.mu.Lock()
+	batch := l.batches[defaults]
+	if batch == nil {
+		batch = l.newBatch(ctx, defaults)
+		l.batches[defaults] = batch
+	}
+
+	if _, ok := batch.waiters[key]; !ok {
+		batch.ids = append(batch.ids, id)
+	}
+	batch.waiters[key] = append(batch.waiters[key], loaderWaiter{id: id, properties: properties, result: result})
+	for _, property := range properties {
+		if !slices.Contains(batch.properties, property) {
+			batch.properties = append(batch.properties, property)
+		}
+	}
+
+	if len(batch.ids) >= l.config.MaxBatchSize {
+		delete(l.batches, defaults)
+		batch.timer.Stop()
+		go l.dispatch(batch)
+	}
+	l.mu.Unlock()
+
+	select {
+	case r := <-result:
+		return r.object, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// newBatch starts a batch that is sent once the wait window ends. l.mu must be held.
+func (l *Loader) newBatch(ctx context.Context, defaults bool) *loaderBatch {
+	batch := &loaderBatch{
+		// The batch outlives the caller that started it, but keeps its values, e.g. for tracing
+		ctx:      context.WithoutCancel(ctx),
+		defaults: defaults,
+		waiters:  make(map[string][]loaderWaiter),
+	}
+	batch.timer = time.AfterFunc(l.config.Wait, func() {
+		l.mu.Lock()
+		if l.batches[defaults] != batch {
+			// Already sent because it filled up
+			l.mu.Unlock()
+			return
+		}
+		delete(l.batches, defaults)
+		l.mu.Unlock()
+
+		l.dispatch(batch)
+	})
+	return batch
+}
+
+// dispatch sends a batch read and fans its results out to the waiting callers
+func (l *Loader) dispatch(batch *loaderBatch) {
+	input := &BatchReadObjectsInput{
+		Properties:            batch.properties,
+		PropertiesWithHistory: []string{},
+		IDProperty:            l.config.IDProperty,
+	}
+	if input.Properties == nil {
+		input.Properties = []string{}
+	}
+	// Results are matched to their IDs by the ID property, which HubSpot only returns when requested
+	if l.config.IDProperty != "" && !slices.Contains(input.Properties, l.config.IDProperty) {
+		input.Properties = append(input.Properties, l.config.IDProperty)
+	}
+	for _, id := range batch.ids {
+		input.Inputs = append(input.Inputs, struct {
+			ID string `json:"id" required:"yes"`
+		}{ID: id})
+	}
+
+	resp, err := l.client.BatchReadObjects(batch.ctx, l.objectType, input, l.config.Options...)
+	if resp == nil {
+		// The whole batch failed
+		for _, waiters := range batch.waiters {
+			for _, waiter := range waiters {
+				waiter.result <- loaderResult{err: err}
+			}
+		}
+		return
+	}
+
+	found := make(map[string]Object, len(resp.Results))
+	for _, object := range resp.Results {
+		found[l.idKey(l.objectID(object))] = object
+	}
+	failed := make(map[string]error)
+	for i := range resp.Errors {
+		batchErr := &resp.Errors[i]
+		if batchErr.Category == "OBJECT_NOT_FOUND" {
+			continue
+		}
+		for _, id := range batchErr.Context["ids"] {
+			failed[l.idKey(id)] = batchErr
+		}
+	}
+
+	for key, waiters := range batch.waiters {
+		for _, waiter := range waiters {
+			if object, ok := found[key]; ok {
+				waiter.result <- loaderResult{object: withProperties(object, waiter.properties)}
+			} else if err, ok := failed[key]; ok {
+				waiter.result <- loaderResult{err: err}
+			} else {
+				waiter.result <- loaderResult{err: &ObjectNotFoundError{ObjectType: l.objectType, ID: waiter.id}}
+			}
+		}
+	}
+}
+
+// withProperties returns a copy of object with only the given properties, or all of them if none are given
+func withProperties(object Object, properties []string) *Object {
+	if len(properties) == 0 {
+		return &object
+	}
+
+	object.Properties = maps.Clone(object.Properties)
+	maps.DeleteFunc(object.Properties, func(property, _ string) bool {
+		return !slices.Contains(properties, property)
+	})
+	return &object
+}
+
+// idKey normalises an ID for matching results to callers
+func (l *Loader) idKey(id string) string {
+	if l.config.CaseInsensitiveID {
+		return strings.ToLower(id)
+	}
+	return id
+}
+
+// objectID returns the ID an object was requested by
+func (l *Loader) objectID(object Object) string {
+	if l.config.IDProperty != "" {
+		return object.Properties[l.config.IDProperty]
+	}
+	return object.ID
+}
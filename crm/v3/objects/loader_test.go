@@ -0,0 +1,256 @@
+package objects
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchReadHandler serves batch reads, returning every requested ID except those in missing
+func batchReadHandler(t *testing.T, requests *[]BatchReadObjectsInput, mu *sync.Mutex, missing ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/crm/v3/objects/contacts/batch/read", r.URL.Path)
+
+		var input BatchReadObjectsInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		mu.Lock()
+		*requests = append(*requests, input)
+		mu.Unlock()
+
+		var results []Object
+		for _, in := range input.Inputs {
+			if slices.Contains(missing, in.ID) {
+				continue
+			}
+			results = append(results, Object{ID: in.ID, Properties: map[string]string{"email": in.ID + "@example.com"}})
+		}
+
+		resp := BatchResponse{Status: "COMPLETE", Results: results}
+		if len(missing) > 0 {
+			resp.NumErrors = 1
+			resp.Errors = []BatchError{{
+				Status:   "error",
+				Category: "OBJECT_NOT_FOUND",
+				Message:  "Could not get some CONTACT objects, they may be deleted or not exist.",
+				Context:  map[string][]string{"ids": missing},
+			}}
+		}
+		body, err := json.Marshal(resp)
+		require.NoError(t, err)
+		respondJSON(w, http.StatusMultiStatus, string(body))
+	}
+}
+
+// TestLoader_CoalescesReads tests that concurrent reads are sent as one batch read
+func TestLoader_CoalescesReads(t *testing.T) {
+	var mu sync.Mutex
+	var requests []BatchReadObjectsInput
+	server, objectClient := setupMockServer(t, batchReadHandler(t, &requests, &mu, "404"))
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: 50 * time.Millisecond})
+
+	ids := []string{"1", "2", "3", "2", "404"}
+	properties := [][]string{{"email"}, {"email", "firstname"}, {"email"}, {"email"}, {"lastname"}}
+	objects := make([]*Object, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			objects[i], errs[i] = loader.Load(context.Background(), ids[i], properties[i]...)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, requests, 1)
+	assert.Len(t, requests[0].Inputs, 4)
+	assert.ElementsMatch(t, []string{"email", "firstname", "lastname"}, requests[0].Properties)
+
+	for i, id := range ids {
+		if id == "404" {
+			var notFound *ObjectNotFoundError
+			require.True(t, errors.As(errs[i], &notFound))
+			assert.Equal(t, "404", notFound.ID)
+			continue
+		}
+		require.NoError(t, errs[i])
+		assert.Equal(t, id, objects[i].ID)
+		assert.Equal(t, id+"@example.com", objects[i].Properties["email"])
+	}
+}
+
+// TestLoader_Properties tests that each caller gets the properties it requested
+func TestLoader_Properties(t *testing.T) {
+	var mu sync.Mutex
+	var requests []BatchReadObjectsInput
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var input BatchReadObjectsInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		mu.Lock()
+		requests = append(requests, input)
+		mu.Unlock()
+
+		// Like HubSpot, return the requested properties or the default ones
+		properties := map[string]string{"email": "ada@example.com", "firstname": "Ada", "lastname": "Lovelace"}
+		if len(input.Properties) > 0 {
+			maps.DeleteFunc(properties, func(property, _ string) bool {
+				return !slices.Contains(input.Properties, property)
+			})
+		}
+		body, err := json.Marshal(BatchResponse{Status: "COMPLETE", Results: []Object{{ID: "1", Properties: properties}}})
+		require.NoError(t, err)
+		respondJSON(w, http.StatusOK, string(body))
+	})
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: 50 * time.Millisecond})
+
+	properties := [][]string{{"email"}, {"firstname"}, nil}
+	objects := make([]*Object, len(properties))
+	var wg sync.WaitGroup
+	for i := range properties {
+		wg.Go(func() {
+			var err error
+			objects[i], err = loader.Load(context.Background(), "1", properties[i]...)
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	// Reads of default properties are batched apart
+	require.Len(t, requests, 2)
+	assert.Equal(t, map[string]string{"email": "ada@example.com"}, objects[0].Properties)
+	assert.Equal(t, map[string]string{"firstname": "Ada"}, objects[1].Properties)
+	assert.Equal(t, map[string]string{"email": "ada@example.com", "firstname": "Ada", "lastname": "Lovelace"}, objects[2].Properties)
+}
+
+// TestLoader_MaxBatchSize tests that full batches are sent without waiting and split at 100 IDs
+func TestLoader_MaxBatchSize(t *testing.T) {
+	var mu sync.Mutex
+	var requests []BatchReadObjectsInput
+	server, objectClient := setupMockServer(t, batchReadHandler(t, &requests, &mu))
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: time.Hour, MaxBatchSize: 500})
+
+	var wg sync.WaitGroup
+	var loaded atomic.Int32
+	for i := range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := loader.Load(context.Background(), fmt.Sprint(i))
+			if assert.NoError(t, err) {
+				loaded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(200), loaded.Load())
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0].Inputs, 100)
+	assert.Len(t, requests[1].Inputs, 100)
+}
+
+// TestLoader_BatchFailure tests that a failed batch read fails every caller
+func TestLoader_BatchFailure(t *testing.T) {
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusBadRequest, `{"status": "error", "message": "Invalid input", "category": "VALIDATION_ERROR"}`)
+	})
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: 10 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"1", "2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := loader.Load(context.Background(), id)
+			var validationErr *ObjectValidationError
+			assert.True(t, errors.As(err, &validationErr))
+		}()
+	}
+	wg.Wait()
+}
+
+// TestLoader_ContextCanceled tests that a canceled caller stops waiting
+func TestLoader_ContextCanceled(t *testing.T) {
+	var mu sync.Mutex
+	var requests []BatchReadObjectsInput
+	server, objectClient := setupMockServer(t, batchReadHandler(t, &requests, &mu))
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := loader.Load(ctx, "1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestLoader_IDProperty tests that reads by a unique property request and match on that property, ignoring the
+// case of emails
+func TestLoader_IDProperty(t *testing.T) {
+	contacts := map[string]map[string]string{
+		"ada@example.com":   {"email": "ada@example.com", "firstname": "Ada", "hs_object_id": "101"},
+		"grace@example.com": {"email": "grace@example.com", "firstname": "Grace", "hs_object_id": "102"},
+	}
+
+	server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var input BatchReadObjectsInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, "email", input.IDProperty)
+
+		// Like HubSpot, only return the requested properties
+		var results []Object
+		for _, in := range input.Inputs {
+			contact := contacts[strings.ToLower(in.ID)]
+			properties := map[string]string{}
+			for _, property := range input.Properties {
+				if value, ok := contact[property]; ok {
+					properties[property] = value
+				}
+			}
+			results = append(results, Object{ID: contact["hs_object_id"], Properties: properties})
+		}
+		body, err := json.Marshal(BatchResponse{Status: "COMPLETE", Results: results})
+		require.NoError(t, err)
+		respondJSON(w, http.StatusOK, string(body))
+	})
+	defer server.Close()
+
+	loader := NewLoader(objectClient, "contacts", LoaderConfig{Wait: 20 * time.Millisecond, IDProperty: "email"})
+
+	var wg sync.WaitGroup
+	for _, email := range []string{"ada@example.com", "Grace@Example.com"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			object, err := loader.Load(context.Background(), email, "firstname")
+			if assert.NoError(t, err) {
+				contact := contacts[strings.ToLower(email)]
+				assert.Equal(t, contact["hs_object_id"], object.ID)
+				assert.Equal(t, map[string]string{"firstname": contact["firstname"]}, object.Properties)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,6 +1,10 @@
 package objects
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
 
 type AssociationCategory string
 
@@ -49,16 +53,7 @@ type Object struct {
 	ObjectWriteTraceID    string                           `json:"objectWriteTraceId"`
 }
 
-type Paging struct {
-	Next struct {
-		After string `json:"after"`
-		Link  string `json:"link"`
-	} `json:"next"`
-	Prev struct {
-		Before string `json:"before"`
-		Link   string `json:"link"`
-	}
-}
+type Paging = client.Paging
 
 type Association struct {
 	Types []struct {
@@ -87,10 +82,7 @@ type PropertyWithHistory struct {
 	UpdatedByUserID int    `json:"updatedByUserId"`
 }
 
-type ListObjectsResponse struct {
-	Results []Object `json:"results"`
-	Paging  Paging   `json:"paging"`
-}
+type ListObjectsResponse = client.CollectionResponse[Object]
 
 type CreateObjectInput struct {
 	Associations []Association     `json:"associations" required:"yes"`
@@ -155,6 +147,11 @@ func (e *BatchError) Error() string {
 	return sb.String()
 }
 
+// Is matches the client sentinel error of the batch error's category, e.g. client.ErrNotFound for OBJECT_NOT_FOUND
+func (e *BatchError) Is(target error) bool {
+	return target != nil && client.CategoryError(e.Category) == target
+}
+
 type BatchReadObjectsInput struct {
 	PropertiesWithHistory []string `json:"propertiesWithHistory" required:"yes"`
 	Inputs                []struct {
@@ -224,8 +221,4 @@ type SearchObjectsInput struct {
 	Query string `json:"query"`
 }
 
-type SearchObjectsResponse struct {
-	Total   int      `json:"total" required:"yes"`
-	Results []Object `json:"results" required:"yes"`
-	Paging  Paging   `json:"paging"`
-}
+type SearchObjectsResponse = client.CollectionResponse[Object]
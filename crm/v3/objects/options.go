@@ -8,7 +8,7 @@ import (
 )
 
 // ObjectsOption is a functional option for Object calls Query Parameters
-type ObjectsOption func(*client.Request)
+type ObjectsOption = client.CallOption
 
 // WithLimit sets the maximum number of objects to return
 func WithLimit(limit int) ObjectsOption {
@@ -0,0 +1,506 @@
+package objects
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/josiah-hester/go-hubspot-sdk/internal/tools"
+)
+
+// TypedClient reads and writes HubSpot objects as T, a struct whose fields are mapped to properties with
+// `hubspot` tags:
+//
+//	type Contact struct {
+//		ID        string     `hubspot:"hs_object_id,readonly"`
+//		FirstName string     `hubspot:"firstname"`
+//		Age       *int       `hubspot:"age"`
+//		Birthday  *time.Time `hubspot:"date_of_birth"`
+//		Interests []string   `hubspot:"interests"`
+//	}
+//
+// Fields may be strings, bools, integers, floats, time.Time, []string for multi-select enumerations, types
+// implementing encoding.TextMarshaler and encoding.TextUnmarshaler, or pointers to any of these. Reads request the
+// tagged properties and decode them into the fields, leaving fields of empty properties at their zero value unless
+// they implement encoding.TextUnmarshaler, which decode "" themselves, e.g. propvalue values as cleared.
+// Writes only send set fields: non-nil pointers and slices, and non-zero values, so a value that must be written
+// as false, 0 or "" needs a pointer field. Fields tagged readonly are read but never written, and untagged fields
+// or fields tagged "-" are ignored.
+type TypedClient[T any] struct {
+	client     *Client
+	objectType string
+	fields     []typedField
+	properties []string
+}
+
+// typedField maps a struct field to a HubSpot property
+type typedField struct {
+	property string
+	index    []int
+	readOnly bool
+}
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// NewTypedClient creates a TypedClient for objects of objectType, e.g. "contacts" or the ID of a custom object type.
+// It returns an error if T is not a struct or has a tagged field of an unsupported type.
+func NewTypedClient[T any](objectsClient *Client, objectType string) (*TypedClient[T], error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typed client requires a struct type, got %s", typ)
+	}
+
+	c := &TypedClient[T]{
+		client:     objectsClient,
+		objectType: objectType,
+	}
+	for _, field := range reflect.VisibleFields(typ) {
+		tag, ok := field.Tag.Lookup("hubspot")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		property, option, _ := strings.Cut(tag, ",")
+		if property == "" {
+			return nil, fmt.Errorf("field %s has an empty hubspot tag", field.Name)
+		}
+		if option != "" && option != "readonly" {
+			return nil, fmt.Errorf("field %s has an unknown hubspot tag option %q", field.Name, option)
+		}
+		if !field.IsExported() || len(field.Index) > 1 && !embeddedByValue(typ, field.Index) {
+			return nil, fmt.Errorf("field %s must be exported and not promoted through a pointer", field.Name)
+		}
+		if !supportedType(field.Type) {
+			return nil, fmt.Errorf("field %s has unsupported type %s", field.Name, field.Type)
+		}
+		if slices.Contains(c.properties, property) {
+			return nil, fmt.Errorf("property %s is mapped to more than one field", property)
+		}
+
+		c.fields = append(c.fields, typedField{property: property, index: field.Index, readOnly: option == "readonly"})
+		c.properties = append(c.properties, property)
+	}
+
+	return c, nil
+}
+
+// Properties returns the properties mapped by T, which are requested by every read
+func (c *TypedClient[T]) Properties() []string {
+	return slices.Clone(c.properties)
+}
+
+// Read reads an object by id or specified idProperty
+//
+// opts: same as ReadObject. WithProperties replaces the properties mapped by T.
+func (c *TypedClient[T]) Read(ctx context.Context, id string, opts ...ObjectsOption) (*T, error) {
+	obj, err := c.client.ReadObject(ctx, c.objectType, id, c.readOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(obj)
+}
+
+// List returns a page of objects
+//
+// opts: same as ListObjects. WithProperties replaces the properties mapped by T.
+func (c *TypedClient[T]) List(ctx context.Context, opts ...ObjectsOption) (*client.CollectionResponse[T], error) {
+	objects, paging, err := c.client.ListObjects(ctx, c.objectType, c.readOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.decodeAll(objects)
+	if err != nil {
+		return nil, err
+	}
+	return &client.CollectionResponse[T]{Results: records, Paging: *paging}, nil
+}
+
+// Search searches for objects. The properties mapped by T are requested unless input sets Properties;
+// input itself is not modified.
+func (c *TypedClient[T]) Search(ctx context.Context, input *SearchObjectsInput, opts ...client.CallOption) (*client.CollectionResponse[T], error) {
+	searchResp, err := c.client.SearchObjects(ctx, c.objectType, c.searchInput(input), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.decodeAll(searchResp.Results)
+	if err != nil {
+		return nil, err
+	}
+	return &client.CollectionResponse[T]{Total: searchResp.Total, Results: records, Paging: searchResp.Paging}, nil
+}
+
+// Create creates an object from the set fields of record
+func (c *TypedClient[T]) Create(ctx context.Context, record *T, opts ...client.CallOption) (*T, error) {
+	properties, err := c.Encode(record)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := c.client.CreateObject(ctx, &CreateObjectInput{Associations: []Association{}, Properties: properties}, c.objectType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(obj)
+}
+
+// Update writes the set fields of record to an object by id or specified idProperty
+//
+// opts:
+// WithIDProperty
+func (c *TypedClient[T]) Update(ctx context.Context, id string, record *T, opts ...ObjectsOption) (*T, error) {
+	properties, err := c.Encode(record)
+	if err != nil {
+		return nil, err
+	}
+	return c.update(ctx, id, properties, opts)
+}
+
+// UpdateChanged writes the fields that differ between original and updated to an object by id or specified
+// idProperty. Fields that were cleared in updated clear their property. If nothing changed, no request is sent
+// and updated is returned.
+//
+// opts: same as Update
+func (c *TypedClient[T]) UpdateChanged(ctx context.Context, id string, original, updated *T, opts ...ObjectsOption) (*T, error) {
+	properties, err := c.Changes(original, updated)
+	if err != nil {
+		return nil, err
+	}
+	if len(properties) == 0 {
+		return updated, nil
+	}
+	return c.update(ctx, id, properties, opts)
+}
+
+// update writes properties to an object and decodes the result
+func (c *TypedClient[T]) update(ctx context.Context, id string, properties map[string]string, opts []ObjectsOption) (*T, error) {
+	obj, err := c.client.UpdateObject(ctx, c.objectType, id, &UpdateObjectInput{Properties: properties}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Decode(obj)
+}
+
+// All iterates over every object, fetching the next page once a page has been consumed
+//
+// opts: same as List. WithAfter sets the cursor of the first page.
+func (c *TypedClient[T]) All(ctx context.Context, opts ...ObjectsOption) iter.Seq2[T, error] {
+	return c.decodeSeq(c.client.All(ctx, c.objectType, c.readOptions(opts)...))
+}
+
+// SearchAll iterates over every result of a search like Client.SearchAll, requesting the properties mapped by T
+// unless input sets Properties
+func (c *TypedClient[T]) SearchAll(ctx context.Context, input *SearchObjectsInput, opts ...client.CallOption) iter.Seq2[T, error] {
+	return c.decodeSeq(c.client.SearchAll(ctx, c.objectType, c.searchInput(input), opts...))
+}
+
+// Decode decodes the properties of obj into a T. A field tagged hs_object_id falls back to the object ID when the
+// property is missing.
+func (c *TypedClient[T]) Decode(obj *Object) (*T, error) {
+	var record T
+	v := reflect.ValueOf(&record).Elem()
+
+	for _, field := range c.fields {
+		value, ok := obj.Properties[field.property]
+		if !ok && field.property == "hs_object_id" {
+			value, ok = obj.ID, true
+		}
+		if !ok {
+			// Properties HubSpot didn't return leave their fields untouched
+			continue
+		}
+		if err := decodeProperty(v.FieldByIndex(field.index), value); err != nil {
+			return nil, fmt.Errorf("failed to decode property %s: %w", field.property, err)
+		}
+	}
+
+	return &record, nil
+}
+
+// Encode encodes the set, writable fields of record into properties
+func (c *TypedClient[T]) Encode(record *T) (map[string]string, error) {
+	v := reflect.ValueOf(record).Elem()
+
+	properties := map[string]string{}
+	for _, field := range c.fields {
+		fv := v.FieldByIndex(field.index)
+		if field.readOnly || !isSet(fv) {
+			continue
+		}
+
+		value, err := encodeProperty(fv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property %s: %w", field.property, err)
+		}
+		properties[field.property] = value
+	}
+
+	return properties, nil
+}
+
+// Changes encodes the writable fields whose values differ between original and updated into properties.
+// Fields that are unset in updated, nil or zero as in Properties, encode as "", which clears the property.
+func (c *TypedClient[T]) Changes(original, updated *T) (map[string]string, error) {
+	ov := reflect.ValueOf(original).Elem()
+	uv := reflect.ValueOf(updated).Elem()
+
+	properties := map[string]string{}
+	for _, field := range c.fields {
+		if field.readOnly {
+			continue
+		}
+
+		before, err := encodeChange(ov.FieldByIndex(field.index))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property %s: %w", field.property, err)
+		}
+		after, err := encodeChange(uv.FieldByIndex(field.index))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property %s: %w", field.property, err)
+		}
+		if before != after {
+			properties[field.property] = after
+		}
+	}
+
+	return properties, nil
+}
+
+// readOptions requests the properties mapped by T ahead of opts
+func (c *TypedClient[T]) readOptions(opts []ObjectsOption) []ObjectsOption {
+	return slices.Concat([]ObjectsOption{WithProperties(c.properties)}, opts)
+}
+
+// searchInput returns a copy of input requesting the properties mapped by T unless it sets Properties
+func (c *TypedClient[T]) searchInput(input *SearchObjectsInput) *SearchObjectsInput {
+	search := *input
+	if len(search.Properties) == 0 {
+		search.Properties = c.properties
+	}
+	return &search
+}
+
+// decodeAll decodes a page of objects
+func (c *TypedClient[T]) decodeAll(objects []Object) ([]T, error) {
+	records := make([]T, 0, len(objects))
+	for i := range objects {
+		record, err := c.Decode(&objects[i])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+// decodeSeq decodes the objects of seq, stopping at the first error
+func (c *TypedClient[T]) decodeSeq(seq iter.Seq2[Object, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for obj, err := range seq {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			record, err := c.Decode(&obj)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if !yield(*record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// embeddedByValue reports whether the promoted field at index is reached through embedded structs only
+func embeddedByValue(typ reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		typ = typ.Field(i).Type
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+	}
+	return true
+}
+
+// supportedType reports whether values of typ can be encoded to and decoded from property values
+func supportedType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == timeType {
+		return true
+	}
+	if ptr := reflect.PointerTo(typ); ptr.Implements(textMarshalerType) && ptr.Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+// isSet reports whether a field holds a value to write
+func isSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		return !v.IsNil()
+	default:
+		return !v.IsZero()
+	}
+}
+
+// encodeChange encodes a field compared by Changes, encoding unset fields as ""
+func encodeChange(v reflect.Value) (string, error) {
+	if !isSet(v) {
+		return "", nil
+	}
+	return encodeProperty(v)
+}
+
+// encodeProperty encodes an addressable field value, encoding unset pointers, slices and zero times as ""
+func encodeProperty(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	}
+	if m, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).String()
+		}
+		return strings.Join(values, ";"), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// decodeProperty decodes a property value into an addressable field. Text unmarshalers decode "" themselves, other
+// fields are left unchanged.
+func decodeProperty(v reflect.Value, value string) error {
+	if value == "" {
+		if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok && v.Kind() != reflect.Pointer && v.Type() != timeType {
+			return u.UnmarshalText([]byte{})
+		}
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeProperty(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, err := tools.ParsePropertyTime(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			// Number properties holding whole numbers may still be sent as e.g. "25.0"
+			f, ferr := strconv.ParseFloat(value, 64)
+			if ferr != nil || f != float64(int64(f)) || v.OverflowInt(int64(f)) {
+				return err
+			}
+			n = int64(f)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			f, ferr := strconv.ParseFloat(value, 64)
+			if ferr != nil || f < 0 || f != float64(uint64(f)) || v.OverflowUint(uint64(f)) {
+				return err
+			}
+			n = uint64(f)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		values := strings.Split(value, ";")
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, s := range values {
+			slice.Index(i).SetString(s)
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
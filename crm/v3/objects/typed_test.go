@@ -0,0 +1,260 @@
+package objects
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLevel is a property type implementing encoding.TextMarshaler and encoding.TextUnmarshaler
+type testLevel int
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	return []byte(strings.Repeat("*", int(l))), nil
+}
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	*l = testLevel(len(text))
+	return nil
+}
+
+// testRecordBase is embedded by testRecord
+type testRecordBase struct {
+	ID        string    `hubspot:"hs_object_id,readonly"`
+	CreatedAt time.Time `hubspot:"createdate,readonly"`
+}
+
+// testRecord is a typed contact
+type testRecord struct {
+	testRecordBase
+	FirstName string    `hubspot:"firstname"`
+	Age       *int      `hubspot:"age"`
+	Score     float64   `hubspot:"score"`
+	Active    *bool     `hubspot:"active"`
+	Interests []string  `hubspot:"interests"`
+	Level     testLevel `hubspot:"level"`
+	Note      string
+	Ignored   string `hubspot:"-"`
+}
+
+// newTestTypedClient creates a typed contacts client
+func newTestTypedClient(t *testing.T, objectClient *Client) *TypedClient[testRecord] {
+	typed, err := NewTypedClient[testRecord](objectClient, "contacts")
+	require.NoError(t, err)
+	return typed
+}
+
+const typedContactJSON = `{
+	"id": "101",
+	"properties": {
+		"createdate": "2024-01-02T03:04:05.000Z",
+		"firstname": "Ada",
+		"age": "36",
+		"score": "9.5",
+		"active": "true",
+		"interests": "math;engines",
+		"level": "***"
+	},
+	"createdAt": "2024-01-02T03:04:05.000Z",
+	"updatedAt": "2024-01-02T03:04:05.000Z",
+	"archived": false
+}`
+
+// TestNewTypedClient tests mapping struct fields to properties
+func TestNewTypedClient(t *testing.T) {
+	t.Run("Derives properties from tags", func(t *testing.T) {
+		typed := newTestTypedClient(t, nil)
+		assert.Equal(t, []string{"hs_object_id", "createdate", "firstname", "age", "score", "active", "interests", "level"}, typed.Properties())
+	})
+
+	t.Run("Rejects invalid types", func(t *testing.T) {
+		_, err := NewTypedClient[string](nil, "contacts")
+		assert.Error(t, err)
+
+		_, err = NewTypedClient[struct {
+			Tags map[string]string `hubspot:"tags"`
+		}](nil, "contacts")
+		assert.ErrorContains(t, err, "unsupported type")
+
+		_, err = NewTypedClient[struct {
+			Name  string `hubspot:"name"`
+			Other string `hubspot:"name"`
+		}](nil, "contacts")
+		assert.ErrorContains(t, err, "more than one field")
+
+		_, err = NewTypedClient[struct {
+			Name string `hubspot:"name,required"`
+		}](nil, "contacts")
+		assert.ErrorContains(t, err, "unknown hubspot tag option")
+	})
+}
+
+// TestTypedClient_Codec tests decoding and encoding properties
+func TestTypedClient_Codec(t *testing.T) {
+	typed := newTestTypedClient(t, nil)
+
+	t.Run("Decode", func(t *testing.T) {
+		var obj Object
+		require.NoError(t, json.Unmarshal([]byte(typedContactJSON), &obj))
+
+		record, err := typed.Decode(&obj)
+		require.NoError(t, err)
+		assert.Equal(t, "101", record.ID)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), record.CreatedAt)
+		assert.Equal(t, "Ada", record.FirstName)
+		require.NotNil(t, record.Age)
+		assert.Equal(t, 36, *record.Age)
+		assert.Equal(t, 9.5, record.Score)
+		require.NotNil(t, record.Active)
+		assert.True(t, *record.Active)
+		assert.Equal(t, []string{"math", "engines"}, record.Interests)
+		assert.Equal(t, testLevel(3), record.Level)
+	})
+
+	t.Run("Decode empty and numeric values", func(t *testing.T) {
+		record, err := typed.Decode(&Object{ID: "7", Properties: map[string]string{"age": "40.0", "createdate": "1704164645000", "active": ""}})
+		require.NoError(t, err)
+		assert.Equal(t, "7", record.ID)
+		assert.Equal(t, 40, *record.Age)
+		assert.Equal(t, time.UnixMilli(1704164645000).UTC(), record.CreatedAt)
+		assert.Nil(t, record.Active)
+		assert.Nil(t, record.Interests)
+	})
+
+	t.Run("Decode invalid value", func(t *testing.T) {
+		_, err := typed.Decode(&Object{Properties: map[string]string{"age": "old"}})
+		assert.ErrorContains(t, err, "property age")
+	})
+
+	t.Run("Encode set fields", func(t *testing.T) {
+		active := false
+		record := &testRecord{
+			testRecordBase: testRecordBase{ID: "101"},
+			FirstName:      "Ada",
+			Active:         &active,
+			Interests:      []string{"math", "engines"},
+			Note:           "not a property",
+		}
+
+		properties, err := typed.Encode(record)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"firstname": "Ada", "active": "false", "interests": "math;engines"}, properties)
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		age := 36
+		original := &testRecord{FirstName: "Ada", Age: &age, Score: 9.5}
+		updated := &testRecord{FirstName: "Ada", Score: 10, Level: 2}
+
+		properties, err := typed.Changes(original, updated)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"age": "", "score": "10", "level": "**"}, properties)
+	})
+
+	t.Run("Changes clears zeroed non-pointer fields", func(t *testing.T) {
+		original := &testRecord{FirstName: "Ada", Score: 9.5}
+		updated := &testRecord{FirstName: "Ada"}
+
+		properties, err := typed.Changes(original, updated)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"score": ""}, properties)
+	})
+}
+
+// TestTypedClient tests typed reads and writes
+func TestTypedClient(t *testing.T) {
+	t.Run("Read requests mapped properties", func(t *testing.T) {
+		server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/crm/v3/objects/contacts/101", r.URL.Path)
+			assert.Equal(t, "hs_object_id,createdate,firstname,age,score,active,interests,level", r.URL.Query().Get("properties"))
+			respondJSON(w, http.StatusOK, typedContactJSON)
+		})
+		defer server.Close()
+
+		record, err := newTestTypedClient(t, objectClient).Read(context.Background(), "101")
+		require.NoError(t, err)
+		assert.Equal(t, "Ada", record.FirstName)
+	})
+
+	t.Run("Create sends set fields", func(t *testing.T) {
+		server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			var body CreateObjectInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"firstname": "Ada"}, body.Properties)
+			respondJSON(w, http.StatusCreated, `{"createResourceId": "101", "entity": `+typedContactJSON+`}`)
+		})
+		defer server.Close()
+
+		record, err := newTestTypedClient(t, objectClient).Create(context.Background(), &testRecord{FirstName: "Ada"})
+		require.NoError(t, err)
+		assert.Equal(t, "101", record.ID)
+	})
+
+	t.Run("UpdateChanged sends changed fields", func(t *testing.T) {
+		requests := 0
+		server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			assert.Equal(t, "PATCH", r.Method)
+			var body UpdateObjectInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"firstname": "Augusta"}, body.Properties)
+			respondJSON(w, http.StatusOK, typedContactJSON)
+		})
+		defer server.Close()
+
+		typed := newTestTypedClient(t, objectClient)
+		original := &testRecord{FirstName: "Ada", Score: 9.5}
+		updated := &testRecord{FirstName: "Augusta", Score: 9.5}
+
+		_, err := typed.UpdateChanged(context.Background(), "101", original, updated)
+		require.NoError(t, err)
+
+		record, err := typed.UpdateChanged(context.Background(), "101", updated, updated)
+		require.NoError(t, err)
+		assert.Same(t, updated, record)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("Search and iterators", func(t *testing.T) {
+		server, objectClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				var body SearchObjectsInput
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Contains(t, body.Properties, "firstname")
+			} else {
+				assert.Contains(t, r.URL.Query().Get("properties"), "firstname")
+			}
+			respondJSON(w, http.StatusOK, `{"total": 1, "results": [`+typedContactJSON+`]}`)
+		})
+		defer server.Close()
+
+		typed := newTestTypedClient(t, objectClient)
+		input := &SearchObjectsInput{Limit: 10}
+
+		searchResp, err := typed.Search(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, 1, searchResp.Total)
+		assert.Equal(t, "Ada", searchResp.Results[0].FirstName)
+		assert.Empty(t, input.Properties)
+
+		for record, err := range typed.SearchAll(context.Background(), input) {
+			require.NoError(t, err)
+			assert.Equal(t, "Ada", record.FirstName)
+		}
+
+		var names []string
+		for record, err := range client.Limit(typed.All(context.Background()), 5) {
+			require.NoError(t, err)
+			names = append(names, record.FirstName)
+		}
+		assert.Equal(t, []string{"Ada"}, names)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -22,12 +24,16 @@ func NewClient(apiClient *client.Client) *Client {
 }
 
 // CreateOrder creates a new order
-func (c *Client) CreateOrder(ctx context.Context, input *CreateOrderInput) (*Order, error) {
+func (c *Client) CreateOrder(ctx context.Context, input *CreateOrderInput, opts ...client.CallOption) (*Order, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -65,12 +71,16 @@ func (c *Client) GetOrder(ctx context.Context, orderID string, opts ...OrderOpti
 }
 
 // UpdateOrder updates an order
-func (c *Client) UpdateOrder(ctx context.Context, orderID string, input *UpdateOrderInput) (*Order, error) {
+func (c *Client) UpdateOrder(ctx context.Context, orderID string, input *UpdateOrderInput, opts ...client.CallOption) (*Order, error) {
 	req := client.NewRequest("PATCH", fmt.Sprintf("/crm/v3/objects/orders/%s", orderID))
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -84,12 +94,36 @@ func (c *Client) UpdateOrder(ctx context.Context, orderID string, input *UpdateO
 	return &order, nil
 }
 
+// CreateOrGetOrder creates a order, or gets the existing order if the create conflicts with one on a unique
+// property value. created reports whether the order was created. opts are applied to both requests.
+func (c *Client) CreateOrGetOrder(ctx context.Context, input *CreateOrderInput, opts ...client.CallOption) (order *Order, created bool, err error) {
+	return client.CreateOrResolve(func() (*Order, error) {
+		return c.CreateOrder(ctx, input, opts...)
+	}, func(existingID string) (*Order, error) {
+		return c.GetOrder(ctx, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateOrder creates a order, or updates the properties of the existing order if the create conflicts
+// with one on a unique property value. created reports whether the order was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateOrder(ctx context.Context, input *CreateOrderInput, opts ...client.CallOption) (order *Order, created bool, err error) {
+	return client.CreateOrResolve(func() (*Order, error) {
+		return c.CreateOrder(ctx, input, opts...)
+	}, func(existingID string) (*Order, error) {
+		return c.UpdateOrder(ctx, existingID, &UpdateOrderInput{Properties: input.Properties}, opts...)
+	})
+}
+
 // ArchiveOrder archives (deletes) an order
-func (c *Client) ArchiveOrder(ctx context.Context, orderID string) error {
+func (c *Client) ArchiveOrder(ctx context.Context, orderID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/orders/%s", orderID))
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
@@ -118,12 +152,17 @@ func (c *Client) ListOrders(ctx context.Context, opts ...OrderOption) (*ListOrde
 }
 
 // BatchReadOrders retrieves multiple orders by ID
-func (c *Client) BatchReadOrders(ctx context.Context, input *BatchReadOrdersInput) (*BatchOrdersResponse, error) {
+func (c *Client) BatchReadOrders(ctx context.Context, input *BatchReadOrdersInput, opts ...client.CallOption) (*BatchOrdersResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders/batch/read")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
+	req.WithReadOnly()
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -138,12 +177,16 @@ func (c *Client) BatchReadOrders(ctx context.Context, input *BatchReadOrdersInpu
 }
 
 // BatchCreateOrders creates multiple orders
-func (c *Client) BatchCreateOrders(ctx context.Context, input *BatchCreateOrdersInput) (*BatchOrdersResponse, error) {
+func (c *Client) BatchCreateOrders(ctx context.Context, input *BatchCreateOrdersInput, opts ...client.CallOption) (*BatchOrdersResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders/batch/create")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -158,12 +201,16 @@ func (c *Client) BatchCreateOrders(ctx context.Context, input *BatchCreateOrders
 }
 
 // BatchUpdateOrders updates multiple orders
-func (c *Client) BatchUpdateOrders(ctx context.Context, input *BatchUpdateOrdersInput) (*BatchOrdersResponse, error) {
+func (c *Client) BatchUpdateOrders(ctx context.Context, input *BatchUpdateOrdersInput, opts ...client.CallOption) (*BatchOrdersResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders/batch/update")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -178,22 +225,36 @@ func (c *Client) BatchUpdateOrders(ctx context.Context, input *BatchUpdateOrders
 }
 
 // BatchArchiveOrders archives multiple orders
-func (c *Client) BatchArchiveOrders(ctx context.Context, input *BatchArchiveOrdersInput) error {
+func (c *Client) BatchArchiveOrders(ctx context.Context, input *BatchArchiveOrdersInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders/batch/archive")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
 
 // SearchOrders searches for orders
-func (c *Client) SearchOrders(ctx context.Context, input *SearchOrdersInput) (*SearchOrdersResponse, error) {
+func (c *Client) SearchOrders(ctx context.Context, input *SearchOrdersInput, opts ...client.CallOption) (*SearchOrdersResponse, error) {
+	return c.searchOrders(ctx, input, opts...)
+}
+
+// searchOrders sends a search whose request body is body, which is the search input
+func (c *Client) searchOrders(ctx context.Context, body any, opts ...client.CallOption) (*SearchOrdersResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/orders/search")
 	req.WithContext(ctx)
 	req.WithResourceType("orders")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -207,3 +268,36 @@ func (c *Client) SearchOrders(ctx context.Context, input *SearchOrdersInput) (*S
 
 	return &searchResp, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every order, fetching the next page once a page has been consumed
+//
+// opts: same as ListOrders. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, opts ...OrderOption) iter.Seq2[Order, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Order], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		listResp, err := c.ListOrders(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[Order]{}, err
+		}
+		return client.Page[Order]{Results: listResp.Results, Next: listResp.Paging.NextAfter()}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *SearchOrdersInput, opts ...client.CallOption) iter.Seq2[Order, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Order], error) {
+		searchResp, err := c.searchOrders(ctx, body, opts...)
+		if err != nil {
+			return client.Page[Order]{}, err
+		}
+		return client.Page[Order]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(order Order) string { return order.ID })
+}
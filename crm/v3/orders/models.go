@@ -1,5 +1,7 @@
 package orders
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 // Order represents a HubSpot order object
 type Order struct {
 	ID                    string                           `json:"id"`
@@ -32,22 +34,13 @@ type UpdateOrderInput struct {
 }
 
 // ListOrdersResponse represents the response from listing orders
-type ListOrdersResponse struct {
-	Results []Order `json:"results"`
-	Paging  *Paging `json:"paging"`
-}
+type ListOrdersResponse = client.CollectionResponse[Order]
 
 // Paging represents pagination information
-type Paging struct {
-	Next *PagingLink `json:"next"`
-	Prev *PagingLink `json:"prev"`
-}
+type Paging = client.Paging
 
 // PagingLink represents a pagination link
-type PagingLink struct {
-	After string `json:"after"`
-	Link  string `json:"link"`
-}
+type PagingLink = client.PagingLink
 
 // BatchReadOrdersInput represents input for batch read
 type BatchReadOrdersInput struct {
@@ -110,8 +103,4 @@ type Filter struct {
 }
 
 // SearchOrdersResponse represents response from search
-type SearchOrdersResponse struct {
-	Total   int     `json:"total"`
-	Results []Order `json:"results"`
-	Paging  *Paging `json:"paging"`
-}
+type SearchOrdersResponse = client.CollectionResponse[Order]
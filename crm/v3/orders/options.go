@@ -8,7 +8,7 @@ import (
 )
 
 // OrderOption represents a functional option for order requests
-type OrderOption func(*client.Request)
+type OrderOption = client.CallOption
 
 // WithProperties specifies which properties to return
 func WithProperties(properties []string) OrderOption {
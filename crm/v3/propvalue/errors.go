@@ -0,0 +1,22 @@
+package propvalue
+
+import (
+	"fmt"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
+
+// ValidationError is returned by Validate when a value cannot be written to a property
+type ValidationError struct {
+	Property string
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value for property %s: %s", e.Property, e.Message)
+}
+
+// Is matches client.ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == client.ErrValidation
+}
@@ -0,0 +1,153 @@
+package propvalue
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/crm/v3/schemas"
+)
+
+// Property types of schemas.Property that Validate checks
+const (
+	TypeBool        = "bool"
+	TypeDate        = "date"
+	TypeDateTime    = "datetime"
+	TypeEnumeration = "enumeration"
+	TypeNumber      = "number"
+)
+
+// Field types of schemas.Property that Validate checks
+const (
+	FieldTypeBooleanCheckbox = "booleancheckbox"
+	FieldTypeCheckbox        = "checkbox"
+)
+
+// Validate checks that v can be written to property. Untouched values, including nil ones, are always valid. Read-only and calculated
+// properties accept no other values, cleared values are valid for any other property, and set values must match
+// the type of property:
+//
+//   - Time: datetime, or date at midnight UTC
+//   - Date: date or datetime
+//   - Decimal: number
+//   - Bool: bool, or enumeration with the booleancheckbox field type
+//   - Enumeration: enumeration with one value, or several for the checkbox field type. Values must be options of
+//     the property unless its options are external.
+//
+// Other Value implementations are only checked for read-only properties. Validation errors are *ValidationError.
+func Validate(property *schemas.Property, v Value) error {
+	if isNil(v) || v.IsUntouched() {
+		return nil
+	}
+	if property.ModificationMetadata.ReadOnlyValue || property.Calculated {
+		return invalid(property, "property is read-only")
+	}
+
+	switch value := v.(type) {
+	case Time:
+		return validateTime(property, value)
+	case *Time:
+		return validateTime(property, *value)
+	case Date:
+		return validateDate(property, value)
+	case *Date:
+		return validateDate(property, *value)
+	case Decimal:
+		return validateDecimal(property, value)
+	case *Decimal:
+		return validateDecimal(property, *value)
+	case Bool:
+		return validateBool(property, value)
+	case *Bool:
+		return validateBool(property, *value)
+	case Enumeration:
+		return validateEnumeration(property, value)
+	case *Enumeration:
+		return validateEnumeration(property, *value)
+	default:
+		return nil
+	}
+}
+
+// isNil reports whether v is nil or a nil pointer, e.g. an unset *Time field
+func isNil(v Value) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// validateTime checks a Time against property
+func validateTime(property *schemas.Property, v Time) error {
+	t, ok := v.Get()
+	switch {
+	case !ok || property.Type == TypeDateTime:
+		return nil
+	case property.Type == TypeDate && t.UTC().Equal(t.UTC().Truncate(24*time.Hour)):
+		return nil
+	case property.Type == TypeDate:
+		return invalid(property, "date values must be midnight UTC")
+	default:
+		return mismatch(property, "datetime")
+	}
+}
+
+// validateDate checks a Date against property
+func validateDate(property *schemas.Property, v Date) error {
+	if v.IsSet() && property.Type != TypeDate && property.Type != TypeDateTime {
+		return mismatch(property, "date")
+	}
+	return nil
+}
+
+// validateDecimal checks a Decimal against property
+func validateDecimal(property *schemas.Property, v Decimal) error {
+	if v.IsSet() && property.Type != TypeNumber {
+		return mismatch(property, "number")
+	}
+	return nil
+}
+
+// validateBool checks a Bool against property
+func validateBool(property *schemas.Property, v Bool) error {
+	if !v.IsSet() || property.Type == TypeBool || property.Type == TypeEnumeration && property.FieldType == FieldTypeBooleanCheckbox {
+		return nil
+	}
+	return mismatch(property, "boolean")
+}
+
+// validateEnumeration checks an Enumeration against property and its options
+func validateEnumeration(property *schemas.Property, v Enumeration) error {
+	values, ok := v.Get()
+	if !ok {
+		return nil
+	}
+	if property.Type != TypeEnumeration {
+		return mismatch(property, "enumeration")
+	}
+	if len(values) > 1 && property.FieldType != FieldTypeCheckbox {
+		return invalid(property, "property accepts a single option")
+	}
+	if property.ExternalOptions {
+		return nil
+	}
+
+	for _, value := range values {
+		if !slices.ContainsFunc(property.Options, func(option schemas.Option) bool { return option.Value == value }) {
+			return invalid(property, fmt.Sprintf("%q is not an option", value))
+		}
+	}
+	return nil
+}
+
+// mismatch returns a ValidationError for a value of the wrong kind
+func mismatch(property *schemas.Property, kind string) error {
+	return invalid(property, fmt.Sprintf("%s value cannot be written to a property of type %s", kind, property.Type))
+}
+
+// invalid returns a ValidationError for property
+func invalid(property *schemas.Property, message string) error {
+	return &ValidationError{Property: property.Name, Message: message}
+}
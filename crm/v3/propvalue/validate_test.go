@@ -0,0 +1,104 @@
+package propvalue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/josiah-hester/go-hubspot-sdk/crm/v3/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProperty returns a property definition
+func testProperty(name, typ, fieldType string, options ...string) *schemas.Property {
+	property := &schemas.Property{Name: name, Type: typ, FieldType: fieldType}
+	for _, option := range options {
+		property.Options = append(property.Options, schemas.Option{Label: option, Value: option})
+	}
+	return property
+}
+
+// TestValidate tests validating values against property definitions
+func TestValidate(t *testing.T) {
+	closeDate := testProperty("closedate", TypeDate, "date")
+	modified := testProperty("hs_lastmodifieddate", TypeDateTime, "date")
+	amount := testProperty("amount", TypeNumber, "number")
+	renewal := testProperty("is_renewal", TypeBool, FieldTypeBooleanCheckbox)
+	legacyRenewal := testProperty("legacy_renewal", TypeEnumeration, FieldTypeBooleanCheckbox, "true", "false")
+	regions := testProperty("regions", TypeEnumeration, FieldTypeCheckbox, "emea", "apac")
+	stage := testProperty("dealstage", TypeEnumeration, "select", "open", "closed")
+
+	var cleared Decimal
+	cleared.Clear()
+
+	valid := []struct {
+		name     string
+		property *schemas.Property
+		value    Value
+	}{
+		{"Date on date", closeDate, NewDate(time.Now())},
+		{"Midnight time on date", closeDate, NewTime(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC))},
+		{"Time on datetime", modified, NewTime(time.Now())},
+		{"Date on datetime", modified, NewDate(time.Now())},
+		{"Decimal on number", amount, NewDecimalFloat(9.5)},
+		{"Bool on bool", renewal, NewBool(true)},
+		{"Bool on boolean checkbox", legacyRenewal, NewBool(false)},
+		{"Options on checkbox", regions, NewEnumeration("emea", "apac")},
+		{"Option on select", stage, NewEnumeration("open")},
+		{"Cleared on any type", stage, &cleared},
+		{"Untouched on any type", amount, Bool{}},
+		{"Nil Time", closeDate, (*Time)(nil)},
+		{"Nil Date", closeDate, (*Date)(nil)},
+		{"Nil Decimal", amount, (*Decimal)(nil)},
+		{"Nil Bool", renewal, (*Bool)(nil)},
+		{"Nil Enumeration", stage, (*Enumeration)(nil)},
+	}
+	for _, tc := range valid {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, Validate(tc.property, tc.value))
+		})
+	}
+
+	invalid := []struct {
+		name     string
+		property *schemas.Property
+		value    Value
+		message  string
+	}{
+		{"Time with hours on date", closeDate, NewTime(time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)), "midnight UTC"},
+		{"Decimal on bool", renewal, NewDecimalInt(1), "type bool"},
+		{"Bool on number", amount, NewBool(true), "type number"},
+		{"Date on number", amount, NewDate(time.Now()), "type number"},
+		{"Enumeration on date", closeDate, NewEnumeration("open"), "type date"},
+		{"Several options on select", stage, NewEnumeration("open", "closed"), "single option"},
+		{"Unknown option", regions, NewEnumeration("latam"), `"latam" is not an option`},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.property, tc.value)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.property.Name, validationErr.Property)
+			assert.Contains(t, validationErr.Message, tc.message)
+			assert.True(t, errors.Is(err, client.ErrValidation))
+		})
+	}
+
+	t.Run("Read-only properties", func(t *testing.T) {
+		readOnly := testProperty("hs_acv", TypeNumber, "number")
+		readOnly.Calculated = true
+
+		assert.ErrorContains(t, Validate(readOnly, NewDecimalInt(1)), "read-only")
+		assert.ErrorContains(t, Validate(readOnly, &cleared), "read-only")
+		assert.NoError(t, Validate(readOnly, Decimal{}))
+		assert.NoError(t, Validate(readOnly, (*Decimal)(nil)))
+	})
+
+	t.Run("External options", func(t *testing.T) {
+		owner := testProperty("hubspot_owner_id", TypeEnumeration, "select")
+		owner.ExternalOptions = true
+		assert.NoError(t, Validate(owner, NewEnumeration("12345")))
+	})
+}
@@ -0,0 +1,402 @@
+// Package propvalue encodes and decodes HubSpot property values
+//
+// HubSpot sends every property as a string: datetimes as epoch milliseconds or ISO 8601 strings, dates as
+// midnight UTC, numbers as decimal strings, booleans as "true" or "false" and multi-select enumerations as
+// semicolon-separated options. The values of this package hold one property each and distinguish three states:
+// set, cleared, which writes "" to clear the property, and untouched, the zero value, which leaves the property
+// out of a write. They implement encoding.TextMarshaler and encoding.TextUnmarshaler, so they can be used as
+// fields of an objects.TypedClient struct, which decodes empty properties as cleared and missing ones as untouched.
+package propvalue
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/internal/tools"
+)
+
+// Value is a property value that can be written to a properties map
+type Value interface {
+	encoding.TextMarshaler
+
+	// IsUntouched reports whether the value leaves its property out of a write
+	IsUntouched() bool
+}
+
+// state is whether a value is untouched, set or cleared
+type state uint8
+
+const (
+	untouched state = iota
+	set
+	cleared
+)
+
+// presence tracks the state of a value
+type presence struct {
+	state state
+}
+
+// IsUntouched reports whether the value leaves its property out of a write
+func (p presence) IsUntouched() bool {
+	return p.state == untouched
+}
+
+// IsSet reports whether the value holds a value
+func (p presence) IsSet() bool {
+	return p.state == set
+}
+
+// IsCleared reports whether the value clears its property
+func (p presence) IsCleared() bool {
+	return p.state == cleared
+}
+
+// Get decodes the property name of properties into a T, e.g. Get[Date](deal.Properties, "closedate").
+// The value is untouched when properties has no such property and cleared when the property is empty.
+func Get[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](properties map[string]string, name string) (T, error) {
+	var v T
+	text, ok := properties[name]
+	if !ok {
+		return v, nil
+	}
+
+	if err := PT(&v).UnmarshalText([]byte(text)); err != nil {
+		return v, fmt.Errorf("failed to decode property %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// Set writes v to the property name of properties, which must not be nil. Set values are encoded, cleared values
+// are written as "" and untouched values are left out.
+func Set(properties map[string]string, name string, v Value) error {
+	if v.IsUntouched() {
+		return nil
+	}
+
+	text, err := v.MarshalText()
+	if err != nil {
+		return fmt.Errorf("failed to encode property %s: %w", name, err)
+	}
+	properties[name] = string(text)
+	return nil
+}
+
+// -------- Time --------
+
+// Time is a datetime property value, encoded as epoch milliseconds
+type Time struct {
+	presence
+	t time.Time
+}
+
+// NewTime returns a Time set to t
+func NewTime(t time.Time) Time {
+	return Time{presence: presence{state: set}, t: t}
+}
+
+// Get returns the time and whether it is set
+func (v Time) Get() (time.Time, bool) {
+	return v.t, v.state == set
+}
+
+// Set sets the time
+func (v *Time) Set(t time.Time) {
+	*v = NewTime(t)
+}
+
+// Clear clears the property when written
+func (v *Time) Clear() {
+	*v = Time{presence: presence{state: cleared}}
+}
+
+// MarshalText encodes the time as epoch milliseconds, or "" unless it is set
+func (v Time) MarshalText() ([]byte, error) {
+	if v.state != set {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatInt(v.t.UnixMilli(), 10)), nil
+}
+
+// UnmarshalText decodes epoch milliseconds, an ISO 8601 datetime or a date. An empty value is cleared.
+func (v *Time) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Clear()
+		return nil
+	}
+
+	t, err := tools.ParsePropertyTime(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid datetime %q", text)
+	}
+	v.Set(t)
+	return nil
+}
+
+// -------- Date --------
+
+// Date is a date property value, held as midnight UTC and encoded as YYYY-MM-DD
+type Date struct {
+	presence
+	t time.Time
+}
+
+// NewDate returns a Date set to the calendar date of t in its location
+func NewDate(t time.Time) Date {
+	return Date{presence: presence{state: set}, t: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// Get returns the date as midnight UTC and whether it is set
+func (v Date) Get() (time.Time, bool) {
+	return v.t, v.state == set
+}
+
+// Set sets the date to the calendar date of t in its location
+func (v *Date) Set(t time.Time) {
+	*v = NewDate(t)
+}
+
+// Clear clears the property when written
+func (v *Date) Clear() {
+	*v = Date{presence: presence{state: cleared}}
+}
+
+// MarshalText encodes the date as YYYY-MM-DD, or "" unless it is set
+func (v Date) MarshalText() ([]byte, error) {
+	if v.state != set {
+		return []byte{}, nil
+	}
+	return []byte(v.t.Format(time.DateOnly)), nil
+}
+
+// UnmarshalText decodes a YYYY-MM-DD date, epoch milliseconds or an ISO 8601 datetime, keeping the UTC date.
+// An empty value is cleared.
+func (v *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Clear()
+		return nil
+	}
+
+	t, err := tools.ParsePropertyTime(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid date %q", text)
+	}
+	v.Set(t.UTC())
+	return nil
+}
+
+// -------- Decimal --------
+
+// decimalPattern matches the decimal numbers HubSpot accepts
+var decimalPattern = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// Decimal is a number property value. It keeps the decimal string HubSpot sends, so no precision is lost.
+type Decimal struct {
+	presence
+	text string
+}
+
+// ParseDecimal returns a Decimal set to the decimal number s, e.g. "1250.75"
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimPrefix(s, "+")
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid number %q", s)
+	}
+	return Decimal{presence: presence{state: set}, text: s}, nil
+}
+
+// NewDecimalInt returns a Decimal set to n
+func NewDecimalInt(n int64) Decimal {
+	return Decimal{presence: presence{state: set}, text: strconv.FormatInt(n, 10)}
+}
+
+// NewDecimalFloat returns a Decimal set to f, in the fewest digits that represent it
+func NewDecimalFloat(f float64) Decimal {
+	return Decimal{presence: presence{state: set}, text: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+// Get returns the decimal string and whether it is set
+func (v Decimal) Get() (string, bool) {
+	return v.text, v.state == set
+}
+
+// Float64 returns the nearest float64 and whether the value is set
+func (v Decimal) Float64() (float64, bool) {
+	if v.state != set {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v.text, 64)
+	return f, err == nil
+}
+
+// Int64 returns the value as an int64 and whether it is set and a whole number in range
+func (v Decimal) Int64() (int64, bool) {
+	r, ok := v.Rat()
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return r.Num().Int64(), true
+}
+
+// Rat returns the exact value and whether it is set
+func (v Decimal) Rat() (*big.Rat, bool) {
+	if v.state != set {
+		return nil, false
+	}
+	return new(big.Rat).SetString(v.text)
+}
+
+// Set sets the value to the decimal number s
+func (v *Decimal) Set(s string) error {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*v = d
+	return nil
+}
+
+// Clear clears the property when written
+func (v *Decimal) Clear() {
+	*v = Decimal{presence: presence{state: cleared}}
+}
+
+// String returns the decimal string, or "" unless it is set
+func (v Decimal) String() string {
+	return v.text
+}
+
+// MarshalText encodes the decimal string, or "" unless it is set
+func (v Decimal) MarshalText() ([]byte, error) {
+	return []byte(v.text), nil
+}
+
+// UnmarshalText decodes a decimal number. An empty value is cleared.
+func (v *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Clear()
+		return nil
+	}
+	return v.Set(string(text))
+}
+
+// -------- Bool --------
+
+// Bool is a boolean property value, encoded as "true" or "false"
+type Bool struct {
+	presence
+	b bool
+}
+
+// NewBool returns a Bool set to b
+func NewBool(b bool) Bool {
+	return Bool{presence: presence{state: set}, b: b}
+}
+
+// Get returns the boolean and whether it is set
+func (v Bool) Get() (bool, bool) {
+	return v.b, v.state == set
+}
+
+// Set sets the boolean
+func (v *Bool) Set(b bool) {
+	*v = NewBool(b)
+}
+
+// Clear clears the property when written
+func (v *Bool) Clear() {
+	*v = Bool{presence: presence{state: cleared}}
+}
+
+// MarshalText encodes the boolean as "true" or "false", or "" unless it is set
+func (v Bool) MarshalText() ([]byte, error) {
+	if v.state != set {
+		return []byte{}, nil
+	}
+	return []byte(strconv.FormatBool(v.b)), nil
+}
+
+// UnmarshalText decodes "true" or "false". An empty value is cleared.
+func (v *Bool) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Clear()
+		return nil
+	}
+
+	b, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", text)
+	}
+	v.Set(b)
+	return nil
+}
+
+// -------- Enumeration --------
+
+// Enumeration is an enumeration property value holding the internal values of its selected options, encoded
+// separated by semicolons. Single-select properties hold one value.
+type Enumeration struct {
+	presence
+	values []string
+}
+
+// NewEnumeration returns an Enumeration set to values. No values clear the property when written.
+func NewEnumeration(values ...string) Enumeration {
+	return Enumeration{presence: presence{state: set}, values: slices.Clone(values)}
+}
+
+// Get returns the selected values and whether they are set
+func (v Enumeration) Get() ([]string, bool) {
+	return slices.Clone(v.values), v.state == set
+}
+
+// Contains reports whether value is selected
+func (v Enumeration) Contains(value string) bool {
+	return slices.Contains(v.values, value)
+}
+
+// Set sets the selected values
+func (v *Enumeration) Set(values ...string) {
+	*v = NewEnumeration(values...)
+}
+
+// Clear clears the property when written
+func (v *Enumeration) Clear() {
+	*v = Enumeration{presence: presence{state: cleared}}
+}
+
+// MarshalText encodes the values separated by semicolons. Values containing a semicolon cannot be encoded.
+func (v Enumeration) MarshalText() ([]byte, error) {
+	for _, value := range v.values {
+		if strings.Contains(value, ";") {
+			return nil, fmt.Errorf("enumeration value %q contains a semicolon", value)
+		}
+	}
+	return []byte(strings.Join(v.values, ";")), nil
+}
+
+// UnmarshalText decodes values separated by semicolons. An empty value is cleared.
+func (v *Enumeration) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		v.Clear()
+		return nil
+	}
+
+	var values []string
+	for value := range strings.SplitSeq(string(text), ";") {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	*v = Enumeration{presence: presence{state: set}, values: values}
+	return nil
+}
@@ -0,0 +1,171 @@
+package propvalue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/crm/v3/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetSet tests reading and writing values in a properties map
+func TestGetSet(t *testing.T) {
+	properties := map[string]string{
+		"closedate":           "2024-03-15",
+		"hs_lastmodifieddate": "2024-03-15T10:30:00.250Z",
+		"amount":              "1250.75",
+		"is_renewal":          "true",
+		"regions":             "emea;apac",
+		"description":         "",
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		closeDate, err := Get[Date](properties, "closedate")
+		require.NoError(t, err)
+		date, ok := closeDate.Get()
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), date)
+
+		modified, err := Get[Time](properties, "hs_lastmodifieddate")
+		require.NoError(t, err)
+		ts, ok := modified.Get()
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2024, 3, 15, 10, 30, 0, 250e6, time.UTC), ts)
+
+		amount, err := Get[Decimal](properties, "amount")
+		require.NoError(t, err)
+		assert.Equal(t, "1250.75", amount.String())
+		f, ok := amount.Float64()
+		require.True(t, ok)
+		assert.Equal(t, 1250.75, f)
+		_, ok = amount.Int64()
+		assert.False(t, ok)
+
+		renewal, err := Get[Bool](properties, "is_renewal")
+		require.NoError(t, err)
+		b, ok := renewal.Get()
+		assert.True(t, ok)
+		assert.True(t, b)
+
+		regions, err := Get[Enumeration](properties, "regions")
+		require.NoError(t, err)
+		values, ok := regions.Get()
+		require.True(t, ok)
+		assert.Equal(t, []string{"emea", "apac"}, values)
+		assert.True(t, regions.Contains("apac"))
+	})
+
+	t.Run("Missing and empty properties", func(t *testing.T) {
+		missing, err := Get[Decimal](properties, "hs_acv")
+		require.NoError(t, err)
+		assert.True(t, missing.IsUntouched())
+
+		empty, err := Get[Enumeration](properties, "description")
+		require.NoError(t, err)
+		assert.True(t, empty.IsCleared())
+		_, ok := empty.Get()
+		assert.False(t, ok)
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		_, err := Get[Bool](map[string]string{"is_renewal": "maybe"}, "is_renewal")
+		assert.ErrorContains(t, err, "property is_renewal")
+
+		_, err = Get[Decimal](map[string]string{"amount": "12,5"}, "amount")
+		assert.Error(t, err)
+
+		_, err = Get[Time](map[string]string{"closedate": "yesterday"}, "closedate")
+		assert.Error(t, err)
+	})
+
+	t.Run("Set distinguishes clear from untouched", func(t *testing.T) {
+		written := map[string]string{}
+
+		var untouched Decimal
+		var cleared Enumeration
+		cleared.Clear()
+
+		require.NoError(t, Set(written, "amount", untouched))
+		require.NoError(t, Set(written, "regions", cleared))
+		require.NoError(t, Set(written, "closedate", NewDate(time.Date(2024, 3, 15, 23, 0, 0, 0, time.FixedZone("PST", -8*3600)))))
+		require.NoError(t, Set(written, "hs_lastmodifieddate", NewTime(time.UnixMilli(1710498600250))))
+		require.NoError(t, Set(written, "is_renewal", NewBool(false)))
+		require.NoError(t, Set(written, "hs_acv", NewDecimalInt(42)))
+
+		assert.Equal(t, map[string]string{
+			"regions":             "",
+			"closedate":           "2024-03-15",
+			"hs_lastmodifieddate": "1710498600250",
+			"is_renewal":          "false",
+			"hs_acv":              "42",
+		}, written)
+	})
+
+	t.Run("Set rejects unencodable values", func(t *testing.T) {
+		err := Set(map[string]string{}, "regions", NewEnumeration("emea;apac"))
+		assert.ErrorContains(t, err, "semicolon")
+	})
+}
+
+// TestDecimal tests parsing and converting decimal values
+func TestDecimal(t *testing.T) {
+	for _, s := range []string{"0", "-3", "+7", "1250.75", ".5", "1.5e3"} {
+		_, err := ParseDecimal(s)
+		assert.NoError(t, err, s)
+	}
+	for _, s := range []string{"", "abc", "1/2", "1,000", "--1"} {
+		_, err := ParseDecimal(s)
+		assert.Error(t, err, s)
+	}
+
+	d, err := ParseDecimal("12345678901234567890.5")
+	require.NoError(t, err)
+	r, ok := d.Rat()
+	require.True(t, ok)
+	assert.Equal(t, "24691357802469135781/2", r.String())
+
+	n, ok := NewDecimalFloat(3).Int64()
+	require.True(t, ok)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, "0.1", NewDecimalFloat(0.1).String())
+}
+
+// TestTypedClientFields tests values as objects.TypedClient fields
+func TestTypedClientFields(t *testing.T) {
+	type deal struct {
+		Name      string      `hubspot:"dealname"`
+		CloseDate Date        `hubspot:"closedate"`
+		Amount    Decimal     `hubspot:"amount"`
+		Regions   Enumeration `hubspot:"regions"`
+	}
+
+	typed, err := objects.NewTypedClient[deal](nil, "deals")
+	require.NoError(t, err)
+
+	original, err := typed.Decode(&objects.Object{Properties: map[string]string{
+		"dealname":  "Renewal",
+		"closedate": "2024-03-15",
+		"amount":    "100",
+		"regions":   "emea",
+	}})
+	require.NoError(t, err)
+	assert.True(t, original.CloseDate.IsSet())
+
+	updated := *original
+	updated.Amount = NewDecimalInt(200)
+	updated.Regions.Clear()
+
+	changes, err := typed.Changes(original, &updated)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"amount": "200", "regions": ""}, changes)
+
+	empty, err := typed.Decode(&objects.Object{Properties: map[string]string{"dealname": "Renewal", "amount": ""}})
+	require.NoError(t, err)
+	assert.True(t, empty.Amount.IsCleared())
+	assert.True(t, empty.CloseDate.IsUntouched())
+
+	properties, err := typed.Encode(&deal{Regions: NewEnumeration("apac")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"regions": "apac"}, properties)
+}
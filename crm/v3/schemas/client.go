@@ -3,6 +3,7 @@ package schemas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
@@ -42,23 +43,23 @@ func (c *Client) GetAllSchemas(ctx context.Context, opts ...SchemaOption) (*GetA
 	}
 
 	var schemas GetAllSchemasResponse
-	if err := tools.NewRequiredTagStruct(&schemas).UnmarhsalJSON(resp.Body); err != nil {
+	if err := json.Unmarshal(resp.Body, &schemas); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal schemas response: %w", err)
 	}
 
-	if len(schemas.Results) == 0 {
-		return &schemas, fmt.Errorf("no schemas found")
-	}
-
 	return &schemas, nil
 }
 
 // GetExistingSchema gets an existing schema by object type
-func (c *Client) GetExistingSchema(ctx context.Context, objectType string) (*Schema, error) {
+func (c *Client) GetExistingSchema(ctx context.Context, objectType string, opts ...client.CallOption) (*Schema, error) {
 	req := client.NewRequest("GET", fmt.Sprintf("/crm-object-schemas/v3/schemas/%s", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("schemas")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -73,12 +74,16 @@ func (c *Client) GetExistingSchema(ctx context.Context, objectType string) (*Sch
 }
 
 // CreateNewSchema creates a new object schema
-func (c *Client) CreateNewSchema(ctx context.Context, input *CreateNewSchemaInput) (*Schema, error) {
+func (c *Client) CreateNewSchema(ctx context.Context, input *CreateNewSchemaInput, opts ...client.CallOption) (*Schema, error) {
 	req := client.NewRequest("POST", "/crm-object-schemas/v3/schemas")
 	req.WithContext(ctx)
 	req.WithResourceType("schemas")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -93,12 +98,16 @@ func (c *Client) CreateNewSchema(ctx context.Context, input *CreateNewSchemaInpu
 }
 
 // CreateNewAssociationSchema creates a new object assocation
-func (c *Client) CreateNewAssociationSchema(ctx context.Context, objectType string, input *CreateNewAssociationSchemaInput) (*CreateNewAssociationSchemaResponse, error) {
+func (c *Client) CreateNewAssociationSchema(ctx context.Context, objectType string, input *CreateNewAssociationSchemaInput, opts ...client.CallOption) (*CreateNewAssociationSchemaResponse, error) {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm-object-schemas/v3/schemas/%s/associations", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("schemas")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -113,12 +122,16 @@ func (c *Client) CreateNewAssociationSchema(ctx context.Context, objectType stri
 }
 
 // UpdateSchema udpates an existing object schema
-func (c *Client) UpdateSchema(ctx context.Context, objectType string, input *UpdateSchemaInput) (*Schema, error) {
+func (c *Client) UpdateSchema(ctx context.Context, objectType string, input *UpdateSchemaInput, opts ...client.CallOption) (*Schema, error) {
 	req := client.NewRequest("PATCH", fmt.Sprintf("/crm-object-schemas/v3/schemas/%s", objectType))
 	req.WithContext(ctx)
 	req.WithResourceType("schemas")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -151,11 +164,15 @@ func (c *Client) DeleteSchema(ctx context.Context, objectType string, opts ...Sc
 }
 
 // RemoveAssociationSchema removes an association schema
-func (c *Client) RemoveAssociationSchema(ctx context.Context, objectType, associationIdentifier string) error {
+func (c *Client) RemoveAssociationSchema(ctx context.Context, objectType, associationIdentifier string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm-object-schemas/v3/schemas/%s/associations/%s", objectType, associationIdentifier))
 	req.WithContext(ctx)
 	req.WithResourceType("schemas")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return err
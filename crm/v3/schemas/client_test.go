@@ -126,7 +126,7 @@ func TestGetAllSchemas_WithArchived(t *testing.T) {
 	assert.True(t, result.Results[0].Archived)
 }
 
-// TestGetAllSchemas_NoResults tests when no schemas found
+// TestGetAllSchemas_NoResults tests that a portal without custom schemas is not an error
 func TestGetAllSchemas_NoResults(t *testing.T) {
 	schemasJSON := `{
 		"results": []
@@ -139,9 +139,10 @@ func TestGetAllSchemas_NoResults(t *testing.T) {
 
 	result, err := schemasClient.GetAllSchemas(context.Background())
 
-	require.Error(t, err)
-	assert.NotNil(t, result)
-	assert.Contains(t, err.Error(), "no schemas found")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.NotNil(t, result.Results)
+	assert.Empty(t, result.Results)
 }
 
 // TestGetAllSchemas_InvalidJSON tests invalid JSON response
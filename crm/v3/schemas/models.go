@@ -1,5 +1,7 @@
 package schemas
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 type DataSensitivity string
 
 const (
@@ -96,9 +98,7 @@ type Option struct {
 	Description  string `json:"description" `
 }
 
-type GetAllSchemasResponse struct {
-	Results []Schema `json:"results" required:"yes"`
-}
+type GetAllSchemasResponse = client.CollectionResponse[Schema]
 
 type CreateNewSchemaInput struct {
 	RequiredProperties []string   `json:"requiredProperties" required:"yes"`
@@ -3,7 +3,7 @@ package schemas
 import "github.com/josiah-hester/go-hubspot-sdk/client"
 
 // SchemaOption is a functional option for the Schemas API
-type SchemaOption func(*client.Request)
+type SchemaOption = client.CallOption
 
 // WithArchived includes archived schemas in results
 func WithArchived() SchemaOption {
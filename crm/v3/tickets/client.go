@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 	"github.com/josiah-hester/go-hubspot-sdk/internal/tools"
@@ -51,20 +53,20 @@ func (c *Client) ListTickets(ctx context.Context, opts ...TicketOption) (*ListTi
 		return nil, fmt.Errorf("failed to unmarshal tickets response: %w", err)
 	}
 
-	if len(tickets.Results) == 0 {
-		return nil, fmt.Errorf("no tickets found")
-	}
-
 	return &tickets, nil
 }
 
 // CreateTicket creates a new ticket
-func (c *Client) CreateTicket(ctx context.Context, input *CreateTicketInput) (*CreateTicketResponse, error) {
+func (c *Client) CreateTicket(ctx context.Context, input *CreateTicketInput, opts ...client.CallOption) (*CreateTicketResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -135,12 +137,45 @@ func (c *Client) UpdateTicket(ctx context.Context, ticketID string, input *Updat
 	return &ticket, nil
 }
 
+// CreateOrGetTicket creates a ticket, or gets the existing ticket if the create conflicts with one on a unique
+// property value. created reports whether the ticket was created. opts are applied to both requests.
+func (c *Client) CreateOrGetTicket(ctx context.Context, input *CreateTicketInput, opts ...client.CallOption) (ticket *Ticket, created bool, err error) {
+	return client.CreateOrResolve(func() (*Ticket, error) {
+		return c.createTicketEntity(ctx, input, opts...)
+	}, func(existingID string) (*Ticket, error) {
+		return c.ReadTicket(ctx, existingID, opts...)
+	})
+}
+
+// CreateOrUpdateTicket creates a ticket, or updates the properties of the existing ticket if the create conflicts
+// with one on a unique property value. created reports whether the ticket was created. opts are applied to both requests.
+func (c *Client) CreateOrUpdateTicket(ctx context.Context, input *CreateTicketInput, opts ...client.CallOption) (ticket *Ticket, created bool, err error) {
+	return client.CreateOrResolve(func() (*Ticket, error) {
+		return c.createTicketEntity(ctx, input, opts...)
+	}, func(existingID string) (*Ticket, error) {
+		return c.UpdateTicket(ctx, existingID, &UpdateTicketInput{Properties: input.Properties}, opts...)
+	})
+}
+
+// createTicketEntity creates a ticket and returns the created ticket
+func (c *Client) createTicketEntity(ctx context.Context, input *CreateTicketInput, opts ...client.CallOption) (*Ticket, error) {
+	resp, err := c.CreateTicket(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Entity, nil
+}
+
 // ArchiveTicket archives a ticket
-func (c *Client) ArchiveTicket(ctx context.Context, ticketID string) error {
+func (c *Client) ArchiveTicket(ctx context.Context, ticketID string, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v3/objects/tickets/%s", ticketID))
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return err
@@ -150,12 +185,16 @@ func (c *Client) ArchiveTicket(ctx context.Context, ticketID string) error {
 }
 
 // MergeTwoTickets merges two tickets together
-func (c *Client) MergeTwoTickets(ctx context.Context, input *MergeTwoTicketsInput) error {
+func (c *Client) MergeTwoTickets(ctx context.Context, input *MergeTwoTicketsInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/merge")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return err
@@ -179,6 +218,7 @@ func (c *Client) BatchReadTickets(ctx context.Context, input *BatchReadTicketsIn
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/batch/read")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
+	req.WithReadOnly()
 	req.WithBody(input)
 
 	for _, opt := range opts {
@@ -199,12 +239,16 @@ func (c *Client) BatchReadTickets(ctx context.Context, input *BatchReadTicketsIn
 }
 
 // BatchCreateTickets Create a batch of tickets. The inputs array can contain a properties object to define property values for the ticket, along with an associations array to define associations with other CRM records.
-func (c *Client) BatchCreateTickets(ctx context.Context, input *BatchCreateTicketsInput) (*BatchTicketsResponse, error) {
+func (c *Client) BatchCreateTickets(ctx context.Context, input *BatchCreateTicketsInput, opts ...client.CallOption) (*BatchTicketsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/batch/create")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -219,12 +263,16 @@ func (c *Client) BatchCreateTickets(ctx context.Context, input *BatchCreateTicke
 }
 
 // BatchUpdateTickets Update a batch of tickets by ID (ticketId) or unique property value (idProperty). Provided property values will be overwritten. Read-only and non-existent properties will result in an error. Properties values can be cleared by passing an empty string.
-func (c *Client) BatchUpdateTickets(ctx context.Context, input *BatchUpdateTicketsInput) (*BatchTicketsResponse, error) {
+func (c *Client) BatchUpdateTickets(ctx context.Context, input *BatchUpdateTicketsInput, opts ...client.CallOption) (*BatchTicketsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/batch/update")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -239,12 +287,16 @@ func (c *Client) BatchUpdateTickets(ctx context.Context, input *BatchUpdateTicke
 }
 
 // BatchCreateOrUpdateTickets Create or update records identified by a unique property value as specified by the idProperty query param. idProperty query param refers to a property whose values are unique for the object.
-func (c *Client) BatchCreateOrUpdateTickets(ctx context.Context, input *BatchCreateOrUpdateTicketsInput) (*BatchTicketsResponse, error) {
+func (c *Client) BatchCreateOrUpdateTickets(ctx context.Context, input *BatchCreateOrUpdateTicketsInput, opts ...client.CallOption) (*BatchTicketsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/batch/createOrUpdate")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -259,12 +311,16 @@ func (c *Client) BatchCreateOrUpdateTickets(ctx context.Context, input *BatchCre
 }
 
 // BatchArchiveTickets Delete a batch of tickets by ID. Deleted tickets can be restored within 90 days of deletion.
-func (c *Client) BatchArchiveTickets(ctx context.Context, input *BatchArchiveTicketsInput) (*BatchTicketsResponse, error) {
+func (c *Client) BatchArchiveTickets(ctx context.Context, input *BatchArchiveTicketsInput, opts ...client.CallOption) (*BatchTicketsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/batch/archive")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -281,11 +337,21 @@ func (c *Client) BatchArchiveTickets(ctx context.Context, input *BatchArchiveTic
 // -------- Search Methods --------
 
 // SearchTickets Search for tickets by filtering on properties, searching through associations, and sorting results.
-func (c *Client) SearchTickets(ctx context.Context, input *SearchTicketsInput) (*SearchTicketsResponse, error) {
+func (c *Client) SearchTickets(ctx context.Context, input *SearchTicketsInput, opts ...client.CallOption) (*SearchTicketsResponse, error) {
+	return c.searchTickets(ctx, input, opts...)
+}
+
+// searchTickets sends a search whose request body is body, which is the search input
+func (c *Client) searchTickets(ctx context.Context, body any, opts ...client.CallOption) (*SearchTicketsResponse, error) {
 	req := client.NewRequest("POST", "/crm/v3/objects/tickets/search")
 	req.WithContext(ctx)
 	req.WithResourceType("tickets")
-	req.WithBody(input)
+	req.WithReadOnly()
+	req.WithBody(body)
+
+	for _, opt := range opts {
+		opt(req)
+	}
 
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
@@ -293,9 +359,42 @@ func (c *Client) SearchTickets(ctx context.Context, input *SearchTicketsInput) (
 	}
 
 	var search SearchTicketsResponse
-	if err := tools.NewRequiredTagStruct(&search).UnmarhsalJSON(resp.Body); err != nil {
+	if err := json.Unmarshal(resp.Body, &search); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal search response: %w", err)
 	}
 
 	return &search, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every ticket, fetching the next page once a page has been consumed
+//
+// opts: same as ListTickets. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, opts ...TicketOption) iter.Seq2[Ticket, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[Ticket], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		listResp, err := c.ListTickets(ctx, pageOpts...)
+		if err != nil {
+			return client.Page[Ticket]{}, err
+		}
+		return client.Page[Ticket]{Results: listResp.Results, Next: listResp.Paging.NextAfter()}, nil
+	})
+}
+
+// SearchAll iterates over every result of a search, fetching the next page once a page has been consumed. Searches
+// without sorts are sorted by hs_object_id and page past HubSpot's 10,000-result ceiling, see client.PaginateSearch.
+// input.After sets the cursor of the first page; input itself is not modified.
+func (c *Client) SearchAll(ctx context.Context, input *SearchTicketsInput, opts ...client.CallOption) iter.Seq2[Ticket, error] {
+	return client.PaginateSearch(ctx, input, func(ctx context.Context, body any) (client.Page[Ticket], error) {
+		searchResp, err := c.searchTickets(ctx, body, opts...)
+		if err != nil {
+			return client.Page[Ticket]{}, err
+		}
+		return client.Page[Ticket]{Results: searchResp.Results, Next: searchResp.Paging.NextAfter()}, nil
+	}, func(ticket Ticket) string { return ticket.ID })
+}
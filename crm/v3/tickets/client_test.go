@@ -118,7 +118,7 @@ func TestListTickets_WithOptions(t *testing.T) {
 	assert.Len(t, result.Results, 1)
 }
 
-// TestListTickets_NoResults tests when no tickets found
+// TestListTickets_NoResults tests that an empty page is not an error
 func TestListTickets_NoResults(t *testing.T) {
 	ticketsJSON := `{
 		"results": []
@@ -131,9 +131,11 @@ func TestListTickets_NoResults(t *testing.T) {
 
 	result, err := ticketsClient.ListTickets(context.Background())
 
-	require.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "no tickets found")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.NotNil(t, result.Results)
+	assert.Empty(t, result.Results)
+	assert.Empty(t, result.Paging.NextAfter())
 }
 
 // TestListTickets_InvalidJSON tests invalid JSON response
@@ -731,3 +733,23 @@ func TestBatchArchiveTickets_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, BatchStatus("COMPLETE"), result.Status)
 }
+
+// TestAll tests that All pages with the after cursor of paging.next
+func TestAll(t *testing.T) {
+	server, ticketsClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if after := r.URL.Query().Get("after"); after != "" {
+			assert.Equal(t, "1", after)
+			respondJSON(w, http.StatusOK, `{"results": [{"id": "2", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": [{"id": "1", "properties": {}, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "archived": false}], "paging": {"next": {"after": "1"}}}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for ticket, err := range ticketsClient.All(context.Background()) {
+		require.NoError(t, err)
+		ids = append(ids, ticket.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
@@ -1,6 +1,10 @@
 package tickets
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
 
 type ObjectError struct {
 	Message     string              `json:"message" required:"yes"`
@@ -45,3 +49,8 @@ func (e *BatchError) Error() string {
 
 	return sb.String()
 }
+
+// Is matches the client sentinel error of the batch error's category, e.g. client.ErrNotFound for OBJECT_NOT_FOUND
+func (e *BatchError) Is(target error) bool {
+	return target != nil && client.CategoryError(e.Category) == target
+}
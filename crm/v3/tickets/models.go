@@ -1,5 +1,7 @@
 package tickets
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 type AssociationCategory string
 
 const (
@@ -73,19 +75,7 @@ type PropertyWithHistory struct {
 	UpdatedByUserID int    `json:"updatedByUserId"`
 }
 
-type ListTicketsResponse struct {
-	Results []Ticket `json:"results" required:"yes"`
-	Paging  struct {
-		Next struct {
-			After string `json:"after" required:"yes"`
-			Link  string `json:"link"`
-		} `json:"next"`
-		Prev struct {
-			Before string `json:"before" required:"yes"`
-			Link   string `json:"link"`
-		} `json:"prev"`
-	} `json:"paging"`
-}
+type ListTicketsResponse = client.CollectionResponse[Ticket]
 
 type CreateTicketInput struct {
 	Associations []struct {
@@ -222,17 +212,4 @@ type SearchTicketsInput struct {
 	Query string `json:"query"`
 }
 
-type SearchTicketsResponse struct {
-	Total   int      `json:"total" required:"yes"`
-	Results []Ticket `json:"results" required:"yes"`
-	Paging  struct {
-		Next struct {
-			After string `json:"after" required:"yes"`
-			Link  string `json:"link"`
-		} `json:"next"`
-		Prev struct {
-			Before string `json:"before" required:"yes"`
-			Link   string `json:"link"`
-		} `json:"prev"`
-	} `json:"paging"`
-}
+type SearchTicketsResponse = client.CollectionResponse[Ticket]
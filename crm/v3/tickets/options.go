@@ -7,7 +7,7 @@ import (
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
 
-type TicketOption func(*client.Request)
+type TicketOption = client.CallOption
 
 // WithLimit sets the maximum number of tickets to return
 func WithLimit(limit int) TicketOption {
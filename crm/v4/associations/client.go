@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/josiah-hester/go-hubspot-sdk/client"
 )
@@ -22,13 +24,17 @@ func NewClient(apiClient *client.Client) *Client {
 }
 
 // CreateAssociation creates an association between two objects and returns the association details
-func (c *Client) CreateAssociation(ctx context.Context, fromObjectType, fromObjectID, toObjectType, toObjectID string, associationSpecs []AssociationSpec) (*AssociationResponse, error) {
+func (c *Client) CreateAssociation(ctx context.Context, fromObjectType, fromObjectID, toObjectType, toObjectID string, associationSpecs []AssociationSpec, opts ...client.CallOption) (*AssociationResponse, error) {
 	req := client.NewRequest("PUT", fmt.Sprintf("/crm/v4/objects/%s/%s/associations/%s/%s",
 		fromObjectType, fromObjectID, toObjectType, toObjectID))
 	req.WithContext(ctx)
 	req.WithResourceType("associations")
 	req.WithBody(associationSpecs)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -43,13 +49,17 @@ func (c *Client) CreateAssociation(ctx context.Context, fromObjectType, fromObje
 }
 
 // DeleteAssociation removes an association between two objects
-func (c *Client) DeleteAssociation(ctx context.Context, fromObjectType, fromObjectID, toObjectType, toObjectID string, associationSpecs []AssociationSpec) error {
+func (c *Client) DeleteAssociation(ctx context.Context, fromObjectType, fromObjectID, toObjectType, toObjectID string, associationSpecs []AssociationSpec, opts ...client.CallOption) error {
 	req := client.NewRequest("DELETE", fmt.Sprintf("/crm/v4/objects/%s/%s/associations/%s/%s",
 		fromObjectType, fromObjectID, toObjectType, toObjectID))
 	req.WithContext(ctx)
 	req.WithResourceType("associations")
 	req.WithBody(associationSpecs)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
@@ -79,36 +89,48 @@ func (c *Client) ListAssociations(ctx context.Context, fromObjectType, fromObjec
 }
 
 // BatchCreateAssociations creates multiple associations
-func (c *Client) BatchCreateAssociations(ctx context.Context, fromObjectType, toObjectType string, input *BatchAssociationInput) error {
+func (c *Client) BatchCreateAssociations(ctx context.Context, fromObjectType, toObjectType string, input *BatchAssociationInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v4/associations/%s/%s/batch/create",
 		fromObjectType, toObjectType))
 	req.WithContext(ctx)
 	req.WithResourceType("associations")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
 
 // BatchDeleteAssociations removes multiple associations
-func (c *Client) BatchDeleteAssociations(ctx context.Context, fromObjectType, toObjectType string, input *BatchAssociationInput) error {
+func (c *Client) BatchDeleteAssociations(ctx context.Context, fromObjectType, toObjectType string, input *BatchAssociationInput, opts ...client.CallOption) error {
 	req := client.NewRequest("POST", fmt.Sprintf("/crm/v4/associations/%s/%s/batch/archive",
 		fromObjectType, toObjectType))
 	req.WithContext(ctx)
 	req.WithResourceType("associations")
 	req.WithBody(input)
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	_, err := c.apiClient.Do(ctx, req)
 	return err
 }
 
 // GetAssociationLabels retrieves all association labels between two object types
-func (c *Client) GetAssociationLabels(ctx context.Context, fromObjectType, toObjectType string) (*GetAssociationLabelsResponse, error) {
+func (c *Client) GetAssociationLabels(ctx context.Context, fromObjectType, toObjectType string, opts ...client.CallOption) (*GetAssociationLabelsResponse, error) {
 	req := client.NewRequest("GET", fmt.Sprintf("/crm/v4/associations/%s/%s/labels",
 		fromObjectType, toObjectType))
 	req.WithContext(ctx)
 	req.WithResourceType("associations")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := c.apiClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
@@ -121,3 +143,23 @@ func (c *Client) GetAssociationLabels(ctx context.Context, fromObjectType, toObj
 
 	return &labelsResp, nil
 }
+
+// -------- Iterators --------
+
+// All iterates over every association of an object, fetching the next page once a page has been consumed
+//
+// opts: same as ListAssociations. WithAfter sets the cursor of the first page.
+func (c *Client) All(ctx context.Context, fromObjectType, fromObjectID, toObjectType string, opts ...AssociationOption) iter.Seq2[AssociatedObject, error] {
+	return client.Paginate(ctx, func(ctx context.Context, cursor string) (client.Page[AssociatedObject], error) {
+		pageOpts := slices.Clip(opts)
+		if cursor != "" {
+			pageOpts = append(pageOpts, WithAfter(cursor))
+		}
+
+		listResp, err := c.ListAssociations(ctx, fromObjectType, fromObjectID, toObjectType, pageOpts...)
+		if err != nil {
+			return client.Page[AssociatedObject]{}, err
+		}
+		return client.Page[AssociatedObject]{Results: listResp.Results, Next: listResp.Paging.NextAfter()}, nil
+	})
+}
@@ -464,3 +464,23 @@ func TestAssociations_MultipleObjectTypes(t *testing.T) {
 		})
 	}
 }
+
+// TestAll tests that All pages with the after cursor of paging.next
+func TestAll(t *testing.T) {
+	server, assocClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if after := r.URL.Query().Get("after"); after != "" {
+			assert.Equal(t, "11", after)
+			respondJSON(w, http.StatusOK, `{"results": [{"toObjectId": "12"}]}`)
+			return
+		}
+		respondJSON(w, http.StatusOK, `{"results": [{"toObjectId": "11"}], "paging": {"next": {"after": "11"}}}`)
+	})
+	defer server.Close()
+
+	var ids []string
+	for assoc, err := range assocClient.All(context.Background(), "contacts", "1", "companies") {
+		require.NoError(t, err)
+		ids = append(ids, assoc.ToObjectID)
+	}
+	assert.Equal(t, []string{"11", "12"}, ids)
+}
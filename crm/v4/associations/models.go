@@ -1,5 +1,7 @@
 package associations
 
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
 // Association category constants
 const (
 	// AssociationCategoryHubSpotDefined represents HubSpot's predefined associations
@@ -65,10 +67,7 @@ type AssociationResponse struct {
 }
 
 // ListAssociationsResponse represents response from listing associations
-type ListAssociationsResponse struct {
-	Results []AssociatedObject `json:"results"`
-	Paging  *Paging            `json:"paging"`
-}
+type ListAssociationsResponse = client.CollectionResponse[AssociatedObject]
 
 // AssociatedObject represents an associated object
 type AssociatedObject struct {
@@ -77,16 +76,10 @@ type AssociatedObject struct {
 }
 
 // Paging represents pagination information
-type Paging struct {
-	Next *PagingLink `json:"next"`
-	Prev *PagingLink `json:"prev"`
-}
+type Paging = client.Paging
 
 // PagingLink represents a pagination link
-type PagingLink struct {
-	After string `json:"after"`
-	Link  string `json:"link"`
-}
+type PagingLink = client.PagingLink
 
 // BatchAssociationResponse represents response from batch operations
 type BatchAssociationResponse struct {
@@ -7,7 +7,7 @@ import (
 )
 
 // AssociationOption represents a functional option for association requests
-type AssociationOption func(*client.Request)
+type AssociationOption = client.CallOption
 
 // WithLimit sets the maximum number of results per page
 func WithLimit(limit int) AssociationOption {
@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"strconv"
+	"time"
+)
+
+// ParsePropertyTime parses a datetime or date property, sent as epoch milliseconds, an ISO 8601 datetime or a
+// YYYY-MM-DD date
+func ParsePropertyTime(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
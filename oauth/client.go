@@ -0,0 +1,45 @@
+// Package oauth specifies the client methods for the HubSpot OAuth API and the install flow for public apps
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
+
+type Client struct {
+	apiClient *client.Client
+}
+
+// NewClient creates a new oauth client
+func NewClient(apiClient *client.Client) *Client {
+	return &Client{
+		apiClient: apiClient,
+	}
+}
+
+// GetAccessTokenInfo returns the portal, user, app and scopes an access token was issued for
+func (c *Client) GetAccessTokenInfo(ctx context.Context, accessToken string, opts ...client.CallOption) (*AccessTokenInfo, error) {
+	req := client.NewRequest("GET", fmt.Sprintf("/oauth/v1/access-tokens/%s", url.PathEscape(accessToken)))
+	req.WithContext(ctx)
+	req.WithResourceType("oauth")
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.apiClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var info AccessTokenInfo
+	if err := json.Unmarshal(resp.Body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal access token info response: %w", err)
+	}
+
+	return &info, nil
+}
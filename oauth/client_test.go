@@ -0,0 +1,100 @@
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMockServer creates a test server with custom handler
+func setupMockServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *Client) {
+	server := httptest.NewServer(handler)
+
+	apiClient, err := client.NewClient(
+		client.WithTimeout(5*time.Second),
+		client.WithBaseURL(server.URL),
+	)
+	require.NoError(t, err)
+
+	return server, NewClient(apiClient)
+}
+
+// respondJSON writes a JSON string response
+func respondJSON(w http.ResponseWriter, statusCode int, jsonString string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(jsonString))
+}
+
+// TestGetAccessTokenInfo_Success tests access token metadata retrieval
+func TestGetAccessTokenInfo_Success(t *testing.T) {
+	server, oauthClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/oauth/v1/access-tokens/access-token", r.URL.Path)
+		assert.Equal(t, "request-1", r.Header.Get("X-Request-Id"))
+		respondJSON(w, http.StatusOK, `{
+			"token": "access-token",
+			"user": "user@example.com",
+			"hub_domain": "example.com",
+			"scopes": ["oauth", "crm.objects.contacts.read"],
+			"hub_id": 62515,
+			"app_id": 456,
+			"expires_in": 1754,
+			"user_id": 123,
+			"token_type": "access"
+		}`)
+	})
+	defer server.Close()
+
+	info, err := oauthClient.GetAccessTokenInfo(context.Background(), "access-token", client.WithHeader("X-Request-Id", "request-1"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 62515, info.HubID)
+	assert.Equal(t, 456, info.AppID)
+	assert.Equal(t, []string{"oauth", "crm.objects.contacts.read"}, info.Scopes)
+}
+
+// TestGetAccessTokenInfo_Error tests error handling for unknown tokens
+func TestGetAccessTokenInfo_Error(t *testing.T) {
+	server, oauthClient := setupMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusNotFound, `{"status": "error", "message": "Token not found"}`)
+	})
+	defer server.Close()
+
+	_, err := oauthClient.GetAccessTokenInfo(context.Background(), "unknown")
+
+	require.Error(t, err)
+	var hubspotErr *client.HubSpotError
+	require.ErrorAs(t, err, &hubspotErr)
+	assert.Equal(t, http.StatusNotFound, hubspotErr.Status)
+}
+
+// TestGetAccessTokenInfo_Logging tests that the access token in the path never reaches the log
+func TestGetAccessTokenInfo_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, `{"token": "CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf3xBKmlwHjX7OIpuIFEavB2-qYAGQsF4", "hub_id": 62515}`)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	apiClient, err := client.NewClient(
+		client.WithBaseURL(server.URL),
+		client.WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	require.NoError(t, err)
+
+	_, err = NewClient(apiClient).GetAccessTokenInfo(context.Background(), "CJSP5qf1KhICAQEYs-gDIIGOBii1hQIyGQAf3xBKmlwHjX7OIpuIFEavB2-qYAGQsF4")
+	require.NoError(t, err)
+
+	logs := buf.String()
+	assert.NotContains(t, logs, "CJSP5qf1KhICAQEYs")
+	assert.Contains(t, logs, "/oauth/v1/access-tokens/[REDACTED]")
+}
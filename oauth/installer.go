@@ -0,0 +1,315 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
+
+// DefaultAuthorizeURL is HubSpot's OAuth authorization page
+const DefaultAuthorizeURL = "https://app.hubspot.com/oauth/authorize"
+
+// DefaultStateCookieName is the cookie used to carry the CSRF state between the start and callback handlers
+const DefaultStateCookieName = "hubspot_oauth_state"
+
+// stateCookieMaxAge bounds how long a user has to complete the install
+const stateCookieMaxAge = 10 * time.Minute
+
+// ErrInvalidState is returned when the callback state is missing or does not match the one issued by the start handler
+var ErrInvalidState = errors.New("oauth state is missing or does not match")
+
+// AuthorizationError is returned when HubSpot redirects back with an error, e.g. the user denied access
+type AuthorizationError struct {
+	Code        string
+	Description string
+}
+
+func (e *AuthorizationError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth authorization failed: %s (%s)", e.Description, e.Code)
+	}
+	return fmt.Sprintf("oauth authorization failed: %s", e.Code)
+}
+
+// InstallerConfig configures an Installer
+type InstallerConfig struct {
+	ClientID       string
+	ClientSecret   string
+	RedirectURI    string
+	Scopes         []string
+	OptionalScopes []string
+
+	// AuthorizeURL defaults to DefaultAuthorizeURL
+	AuthorizeURL string
+	// TokenURL defaults to client.DefaultTokenURL
+	TokenURL string
+	// HTTPClient is used for token requests, defaults to a client with a 30 second timeout
+	HTTPClient *http.Client
+
+	// Store receives the tokens for every installed portal and every later refresh
+	Store TokenStore
+
+	// ClientOptions are applied to every portal client created by the installer
+	ClientOptions []client.Option
+
+	// StateCookieName defaults to DefaultStateCookieName
+	StateCookieName string
+	// InsecureStateCookie lets the state cookie be sent over plain HTTP, e.g. for local development.
+	// The cookie is Secure by default, since the handlers often run behind a TLS-terminating proxy.
+	InsecureStateCookie bool
+
+	// OnInstall is called by the callback handler after a successful install.
+	// Defaults to responding with 200 OK.
+	OnInstall func(w http.ResponseWriter, r *http.Request, install *Installation)
+
+	// OnError is called by the callback handler when the install fails.
+	// Defaults to responding with 400 for state and authorization errors, and 502 otherwise.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Installer implements the OAuth install flow for a HubSpot public app
+type Installer struct {
+	config InstallerConfig
+}
+
+// NewInstaller creates a new Installer
+func NewInstaller(cfg InstallerConfig) (*Installer, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("oauth client ID and client secret are required")
+	}
+	if cfg.RedirectURI == "" {
+		return nil, fmt.Errorf("oauth redirect URI is required")
+	}
+	if cfg.Store == nil {
+		return nil, fmt.Errorf("oauth token store is required")
+	}
+	if cfg.AuthorizeURL == "" {
+		cfg.AuthorizeURL = DefaultAuthorizeURL
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = client.DefaultTokenURL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.StateCookieName == "" {
+		cfg.StateCookieName = DefaultStateCookieName
+	}
+	if cfg.OnInstall == nil {
+		cfg.OnInstall = func(w http.ResponseWriter, r *http.Request, install *Installation) {
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	if cfg.OnError == nil {
+		cfg.OnError = defaultOnError
+	}
+
+	return &Installer{
+		config: cfg,
+	}, nil
+}
+
+// AuthorizeURL returns the HubSpot authorization URL for the configured scopes and the given state
+func (i *Installer) AuthorizeURL(state string) string {
+	values := url.Values{}
+	values.Set("client_id", i.config.ClientID)
+	values.Set("redirect_uri", i.config.RedirectURI)
+	if len(i.config.Scopes) > 0 {
+		values.Set("scope", strings.Join(i.config.Scopes, " "))
+	}
+	if len(i.config.OptionalScopes) > 0 {
+		values.Set("optional_scope", strings.Join(i.config.OptionalScopes, " "))
+	}
+	if state != "" {
+		values.Set("state", state)
+	}
+	return i.config.AuthorizeURL + "?" + values.Encode()
+}
+
+// StartHandler returns a handler that issues a CSRF state cookie and redirects to the authorization URL
+func (i *Installer) StartHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, err := newState()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     i.config.StateCookieName,
+			Value:    state,
+			Path:     "/",
+			MaxAge:   int(stateCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			Secure:   !i.config.InsecureStateCookie,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, i.AuthorizeURL(state), http.StatusFound)
+	})
+}
+
+// CallbackHandler returns a handler for the redirect URI.
+// It validates the state, exchanges the code, stores the tokens and calls OnInstall.
+func (i *Installer) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The state is single use
+		http.SetCookie(w, &http.Cookie{
+			Name:     i.config.StateCookieName,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   !i.config.InsecureStateCookie,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		query := r.URL.Query()
+		if code := query.Get("error"); code != "" {
+			i.config.OnError(w, r, &AuthorizationError{Code: code, Description: query.Get("error_description")})
+			return
+		}
+
+		cookie, err := r.Cookie(i.config.StateCookieName)
+		if err != nil || !validState(cookie.Value, query.Get("state")) {
+			i.config.OnError(w, r, ErrInvalidState)
+			return
+		}
+
+		code := query.Get("code")
+		if code == "" {
+			i.config.OnError(w, r, &AuthorizationError{Code: "missing_code", Description: "callback did not include an authorization code"})
+			return
+		}
+
+		install, err := i.Exchange(r.Context(), code)
+		if err != nil {
+			i.config.OnError(w, r, err)
+			return
+		}
+
+		i.config.OnInstall(w, r, install)
+	})
+}
+
+// Exchange exchanges an authorization code for tokens, looks up the installing portal,
+// stores the tokens and returns a client for that portal
+func (i *Installer) Exchange(ctx context.Context, code string) (*Installation, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", i.config.ClientID)
+	form.Set("client_secret", i.config.ClientSecret)
+	form.Set("redirect_uri", i.config.RedirectURI)
+	form.Set("code", code)
+
+	token, err := client.RequestToken(ctx, i.config.HTTPClient, i.config.TokenURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	// The portal isn't known until the token is looked up, so authenticate the lookup with the token itself
+	lookupClient, err := client.NewClient(append(i.clientOptions(), client.WithAccessToken(token.AccessToken))...)
+	if err != nil {
+		return nil, err
+	}
+	info, err := NewClient(lookupClient).GetAccessTokenInfo(ctx, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up installing portal: %w", err)
+	}
+
+	if err := i.config.Store.SaveToken(ctx, info.HubID, *token); err != nil {
+		return nil, fmt.Errorf("failed to store token for portal %d: %w", info.HubID, err)
+	}
+
+	portalClient, err := i.NewPortalClient(info.HubID, *token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Installation{
+		PortalID: info.HubID,
+		Token:    *token,
+		Info:     info,
+		Client:   portalClient,
+	}, nil
+}
+
+// NewPortalClient creates a client for a portal that refreshes its token automatically and saves refreshed tokens to the store
+func (i *Installer) NewPortalClient(portalID int, token client.Token) (*client.Client, error) {
+	source, err := i.newTokenSource(portalID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewClient(append(i.clientOptions(), client.WithTokenSource(source))...)
+}
+
+// PortalTokenSource loads the stored token for a portal and returns a refreshing token source for it.
+// It can be used as a client.TokenSourceFunc for a client.Pool.
+func (i *Installer) PortalTokenSource(ctx context.Context, portalID int) (client.TokenSource, error) {
+	token, err := i.config.Store.LoadToken(ctx, portalID)
+	if err != nil {
+		return nil, err
+	}
+	return i.newTokenSource(portalID, *token)
+}
+
+// NewPool creates a client.Pool of portal clients backed by the token store
+func (i *Installer) NewPool(idleTimeout time.Duration) (*client.Pool, error) {
+	return client.NewPool(i.PortalTokenSource, idleTimeout, i.config.ClientOptions...)
+}
+
+// newTokenSource creates a token source for a portal that saves refreshed tokens to the store
+func (i *Installer) newTokenSource(portalID int, token client.Token) (client.TokenSource, error) {
+	return client.NewOAuthTokenSource(client.OAuthConfig{
+		ClientID:     i.config.ClientID,
+		ClientSecret: i.config.ClientSecret,
+		RedirectURI:  i.config.RedirectURI,
+		TokenURL:     i.config.TokenURL,
+		HTTPClient:   i.config.HTTPClient,
+		OnRefresh: func(token client.Token) {
+			// A failed save leaves the previous refresh token in the store, which HubSpot keeps accepting
+			_ = i.config.Store.SaveToken(context.Background(), portalID, token)
+		},
+	}, token)
+}
+
+// clientOptions returns a copy of the configured client options that is safe to append to
+func (i *Installer) clientOptions() []client.Option {
+	return append([]client.Option(nil), i.config.ClientOptions...)
+}
+
+// newState returns a random URL-safe CSRF state
+func newState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// validState compares the issued and returned states in constant time
+func validState(issued, returned string) bool {
+	if issued == "" || returned == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(issued), []byte(returned)) == 1
+}
+
+// defaultOnError responds with a status matching the kind of install failure
+func defaultOnError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadGateway
+	var authErr *AuthorizationError
+	if errors.Is(err, ErrInvalidState) || errors.As(err, &authErr) {
+		status = http.StatusBadRequest
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,215 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupInstaller creates an installer backed by a mock HubSpot serving the token and token info endpoints
+func setupInstaller(t *testing.T, onInstall func(w http.ResponseWriter, r *http.Request, install *Installation)) (*httptest.Server, *Installer, *MemoryTokenStore) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/v1/token":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "https://app.example.com/oauth/callback", r.PostForm.Get("redirect_uri"))
+			if r.PostForm.Get("code") != "good-code" {
+				respondJSON(w, http.StatusBadRequest, `{"status": "BAD_AUTH_CODE", "message": "missing or unknown auth code"}`)
+				return
+			}
+			respondJSON(w, http.StatusOK, `{"access_token": "access", "refresh_token": "refresh", "expires_in": 1800}`)
+		case "/oauth/v1/access-tokens/access":
+			respondJSON(w, http.StatusOK, `{"token": "access", "hub_id": 62515, "scopes": ["oauth"]}`)
+		default:
+			assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+			respondJSON(w, http.StatusOK, `{}`)
+		}
+	}))
+
+	store := NewMemoryTokenStore()
+	installer, err := NewInstaller(InstallerConfig{
+		ClientID:       "client-id",
+		ClientSecret:   "client-secret",
+		RedirectURI:    "https://app.example.com/oauth/callback",
+		Scopes:         []string{"oauth", "crm.objects.contacts.read"},
+		OptionalScopes: []string{"crm.objects.deals.read"},
+		TokenURL:       server.URL + "/oauth/v1/token",
+		Store:          store,
+		ClientOptions:  []client.Option{client.WithBaseURL(server.URL), client.WithTimeout(5 * time.Second)},
+		OnInstall:      onInstall,
+	})
+	require.NoError(t, err)
+
+	return server, installer, store
+}
+
+// startInstall runs the start handler and returns the state cookie and state sent to HubSpot
+func startInstall(t *testing.T, installer *Installer) (*http.Cookie, string) {
+	rec := httptest.NewRecorder()
+	installer.StartHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/oauth/install", nil))
+
+	require.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return cookies[0], location.Query().Get("state")
+}
+
+// TestAuthorizeURL tests authorize URL construction
+func TestAuthorizeURL(t *testing.T) {
+	server, installer, _ := setupInstaller(t, nil)
+	defer server.Close()
+
+	authorizeURL, err := url.Parse(installer.AuthorizeURL("abc"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "app.hubspot.com", authorizeURL.Host)
+	assert.Equal(t, "/oauth/authorize", authorizeURL.Path)
+	query := authorizeURL.Query()
+	assert.Equal(t, "client-id", query.Get("client_id"))
+	assert.Equal(t, "https://app.example.com/oauth/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "oauth crm.objects.contacts.read", query.Get("scope"))
+	assert.Equal(t, "crm.objects.deals.read", query.Get("optional_scope"))
+	assert.Equal(t, "abc", query.Get("state"))
+}
+
+// TestInstallFlow_Success tests a complete install through the start and callback handlers
+func TestInstallFlow_Success(t *testing.T) {
+	var installed *Installation
+	server, installer, store := setupInstaller(t, func(w http.ResponseWriter, r *http.Request, install *Installation) {
+		installed = install
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	cookie, state := startInstall(t, installer)
+	assert.NotEmpty(t, state)
+	assert.Equal(t, state, cookie.Value)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+
+	req := httptest.NewRequest("GET", "/oauth/callback?code=good-code&state="+url.QueryEscape(state), nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	installer.CallbackHandler().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.NotNil(t, installed)
+	assert.Equal(t, 62515, installed.PortalID)
+	assert.Equal(t, "access", installed.Token.AccessToken)
+
+	stored, err := store.LoadToken(context.Background(), 62515)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", stored.RefreshToken)
+
+	// The portal client is ready to use
+	_, err = installed.Client.Do(context.Background(), client.NewRequest("GET", "/crm/v3/objects/contacts"))
+	require.NoError(t, err)
+}
+
+// TestInstallFlow_InsecureStateCookie tests that the state cookie can be sent over plain HTTP
+func TestInstallFlow_InsecureStateCookie(t *testing.T) {
+	server, installer, _ := setupInstaller(t, nil)
+	defer server.Close()
+	installer.config.InsecureStateCookie = true
+
+	cookie, _ := startInstall(t, installer)
+	assert.False(t, cookie.Secure)
+}
+
+// TestInstallFlow_InvalidState tests CSRF state validation
+func TestInstallFlow_InvalidState(t *testing.T) {
+	server, installer, _ := setupInstaller(t, nil)
+	defer server.Close()
+
+	cookie, _ := startInstall(t, installer)
+
+	t.Run("Mismatched state", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/oauth/callback?code=good-code&state=forged", nil)
+		req.AddCookie(cookie)
+		rec := httptest.NewRecorder()
+		installer.CallbackHandler().ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Missing cookie", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/oauth/callback?code=good-code&state="+url.QueryEscape(cookie.Value), nil)
+		rec := httptest.NewRecorder()
+		installer.CallbackHandler().ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+// TestInstallFlow_Errors tests authorization and exchange failures
+func TestInstallFlow_Errors(t *testing.T) {
+	server, installer, _ := setupInstaller(t, nil)
+	defer server.Close()
+
+	t.Run("Access denied", func(t *testing.T) {
+		var gotErr error
+		installer.config.OnError = func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			defaultOnError(w, r, err)
+		}
+		req := httptest.NewRequest("GET", "/oauth/callback?error=access_denied&error_description=denied", nil)
+		rec := httptest.NewRecorder()
+		installer.CallbackHandler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var authErr *AuthorizationError
+		require.ErrorAs(t, gotErr, &authErr)
+		assert.Equal(t, "access_denied", authErr.Code)
+	})
+
+	t.Run("Bad authorization code", func(t *testing.T) {
+		_, err := installer.Exchange(context.Background(), "bad-code")
+		require.Error(t, err)
+		var hubspotErr *client.HubSpotError
+		require.ErrorAs(t, err, &hubspotErr)
+		assert.Equal(t, http.StatusBadRequest, hubspotErr.Status)
+	})
+}
+
+// TestNewInstaller_Validation tests required configuration
+func TestNewInstaller_Validation(t *testing.T) {
+	_, err := NewInstaller(InstallerConfig{ClientID: "id", ClientSecret: "secret", RedirectURI: "https://app.example.com"})
+	require.Error(t, err)
+
+	_, err = NewInstaller(InstallerConfig{ClientID: "id", ClientSecret: "secret", Store: NewMemoryTokenStore()})
+	require.Error(t, err)
+}
+
+// TestInstallerPool tests creating portal clients from stored tokens
+func TestInstallerPool(t *testing.T) {
+	server, installer, store := setupInstaller(t, nil)
+	defer server.Close()
+
+	pool, err := installer.NewPool(time.Minute)
+	require.NoError(t, err)
+
+	_, err = pool.Get(context.Background(), 62515)
+	require.Error(t, err)
+	var notFound *TokenNotFoundError
+	require.ErrorAs(t, err, &notFound)
+
+	require.NoError(t, store.SaveToken(context.Background(), 62515, client.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}))
+
+	portalClient, err := pool.Get(context.Background(), 62515)
+	require.NoError(t, err)
+	_, err = portalClient.Do(context.Background(), client.NewRequest("GET", "/crm/v3/objects/contacts"))
+	require.NoError(t, err)
+}
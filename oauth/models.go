@@ -0,0 +1,25 @@
+package oauth
+
+import "github.com/josiah-hester/go-hubspot-sdk/client"
+
+type AccessTokenInfo struct {
+	Token     string   `json:"token"`
+	User      string   `json:"user"`
+	HubDomain string   `json:"hub_domain"`
+	Scopes    []string `json:"scopes"`
+	HubID     int      `json:"hub_id"`
+	AppID     int      `json:"app_id"`
+	ExpiresIn int      `json:"expires_in"`
+	UserID    int      `json:"user_id"`
+	TokenType string   `json:"token_type"`
+}
+
+// Installation is the result of a completed install of the app into a portal
+type Installation struct {
+	PortalID int
+	Token    client.Token
+	Info     *AccessTokenInfo
+
+	// Client is authenticated for the installing portal and refreshes its token automatically
+	Client *client.Client
+}
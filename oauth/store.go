@@ -0,0 +1,60 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/josiah-hester/go-hubspot-sdk/client"
+)
+
+// TokenStore persists the tokens issued for each installed portal
+//
+// Implementations must be safe for concurrent use
+type TokenStore interface {
+	SaveToken(ctx context.Context, portalID int, token client.Token) error
+	LoadToken(ctx context.Context, portalID int) (*client.Token, error)
+}
+
+// TokenNotFoundError is returned by a TokenStore when no token is stored for a portal
+type TokenNotFoundError struct {
+	PortalID int
+}
+
+func (e *TokenNotFoundError) Error() string {
+	return fmt.Sprintf("no token stored for portal %d", e.PortalID)
+}
+
+// MemoryTokenStore is an in-memory TokenStore, useful for tests and single-process apps
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[int]client.Token
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[int]client.Token),
+	}
+}
+
+// SaveToken implements TokenStore
+func (s *MemoryTokenStore) SaveToken(ctx context.Context, portalID int, token client.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[portalID] = token
+	return nil
+}
+
+// LoadToken implements TokenStore
+func (s *MemoryTokenStore) LoadToken(ctx context.Context, portalID int) (*client.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	token, ok := s.tokens[portalID]
+	if !ok {
+		return nil, &TokenNotFoundError{PortalID: portalID}
+	}
+	return &token, nil
+}